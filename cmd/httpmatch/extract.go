@@ -0,0 +1,117 @@
+package main
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// namedExtractor builds an extractor from the argument of an --extract spec, e.g. "X-Id" for "header:X-Id".
+type namedExtractor struct {
+	// arg is true if the extractor requires an argument.
+	arg bool
+	// response is true if the extractor expects a *http.Response.  The other extractors are passed the request of a
+	// response.
+	response bool
+	build    func(arg string) extractor.Extractor
+}
+
+var namedExtractors = map[string]namedExtractor{
+	"method":            {build: fixed(extractor.ExtractMethod)},
+	"path":              {build: fixed(extractor.ExtractPath)},
+	"raw-path":          {build: fixed(extractor.ExtractRawPath)},
+	"uri":               {build: fixed(extractor.ExtractRequestURI)},
+	"host":              {build: fixed(extractor.ExtractHost)},
+	"hostname":          {build: fixed(extractor.ExtractHostname)},
+	"port":              {build: fixed(func() extractor.Extractor { return extractor.ExtractPort() })},
+	"scheme":            {build: fixed(func() extractor.Extractor { return extractor.ExtractScheme() })},
+	"client-ip":         {build: fixed(func() extractor.Extractor { return extractor.ExtractClientIP() })},
+	"body":              {build: fixed(extractor.ExtractBody)},
+	"content-type":      {build: fixed(extractor.ExtractContentType)},
+	"content-length":    {build: fixed(extractor.ExtractContentLength)},
+	"bearer":            {build: fixed(extractor.ExtractBearerToken)},
+	"basic-user":        {build: fixed(extractor.ExtractBasicAuthUser)},
+	"header":            {arg: true, build: extractor.ExtractHeader},
+	"query":             {arg: true, build: extractor.ExtractQueryParameter},
+	"cookie":            {arg: true, build: extractor.ExtractCookie},
+	"form":              {arg: true, build: extractor.ExtractFormField},
+	"xpath":             {arg: true, build: extractor.ExtractXPathString},
+	"jsonpath":          {arg: true, build: extractor.ExtractJSONPath},
+	"jsonpointer":       {arg: true, build: extractor.ExtractJSONPointer},
+	"path-params":       {arg: true, build: extractor.ExtractPathParameters},
+	"status":            {response: true, build: fixed(extractor.ExtractStatusCode)},
+	"status-class":      {response: true, build: fixed(extractor.ExtractStatusClass)},
+	"response-body":     {response: true, build: fixed(extractor.ExtractResponseBody)},
+	"response-header":   {arg: true, response: true, build: extractor.ExtractResponseHeader},
+	"response-xpath":    {arg: true, response: true, build: extractor.ExtractResponseXPathString},
+	"response-jsonpath": {arg: true, response: true, build: extractor.ExtractResponseJSONPath},
+}
+
+// fixed adapts the constructor of an extractor without arguments.
+func fixed(constructor func() extractor.Extractor) func(string) extractor.Extractor {
+	return func(string) extractor.Extractor {
+		return constructor()
+	}
+}
+
+// extraction is an extractor parsed from an --extract spec.
+type extraction struct {
+	spec      string
+	response  bool
+	extractor extractor.Extractor
+}
+
+// parseExtraction parses an --extract spec, i.e. the name of an extractor optionally followed by a colon and its
+// argument, e.g. "method", "header:X-Id" or "xpath://order/id".
+func parseExtraction(spec string) (x *extraction, err error) {
+	name, arg, hasArg := strings.Cut(spec, ":")
+	named, ok := namedExtractors[name]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unknown extractor %q in %q, expected one of %s", name, spec, extractorNames())
+	case named.arg && (!hasArg || arg == ""):
+		return nil, fmt.Errorf("extractor %q requires an argument, e.g. %s:<arg>", name, name)
+	case !named.arg && hasArg:
+		return nil, fmt.Errorf("extractor %q does not take an argument", name)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			x, err = nil, fmt.Errorf("invalid extractor %q: %v", spec, r)
+		}
+	}()
+	return &extraction{spec: spec, response: named.response, extractor: named.build(arg)}, nil
+}
+
+// extract extracts the value from the request or the response.  Request extractors are passed the request of a
+// response.
+func (x *extraction) extract(v interface{}) (interface{}, error) {
+	if resp, ok := v.(*http.Response); ok && !x.response {
+		v = resp.Request
+	}
+	return extractor.ExtractE(x.extractor, v)
+}
+
+func extractorNames() string {
+	names := make([]string, 0, len(namedExtractors))
+	for name, named := range namedExtractors {
+		if named.arg {
+			name += ":<arg>"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
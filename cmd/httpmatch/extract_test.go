@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseExtraction(t *testing.T) {
+	req := httptest.NewRequest("POST", "/orders?id=7", strings.NewReader(`{"order": {"id": 7}}`))
+	req.Header.Set("X-Id", "42")
+	resp := &http.Response{StatusCode: 404, Header: http.Header{"X-Trace": {"abc"}},
+		Body: io.NopCloser(strings.NewReader("<error>gone</error>")), Request: req}
+
+	tests := []struct {
+		spec     string
+		v        interface{}
+		expected interface{}
+	}{
+		{"method", req, "POST"},
+		{"header:X-Id", req, "42"},
+		{"query:id", req, "7"},
+		{"jsonpath:$.order.id", req, 7.0},
+		{"jsonpointer:/order/id", req, 7.0},
+		{"header:X-Id", resp, "42"},
+		{"status", resp, 404},
+		{"status-class", resp, "4xx"},
+		{"response-header:X-Trace", resp, "abc"},
+		{"response-xpath://error", resp, "gone"},
+	}
+	for _, test := range tests {
+		x, err := parseExtraction(test.spec)
+		assert.NoError(t, err, test.spec)
+		value, err := x.extract(test.v)
+		assert.NoError(t, err, test.spec)
+		assert.Equal(t, test.expected, value, test.spec)
+	}
+
+	x, err := parseExtraction("status")
+	assert.NoError(t, err)
+	_, err = x.extract(req)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+
+	_, err = parseExtraction("path-params:/orders/{")
+	assert.Error(t, err)
+	_, err = parseExtraction("query:")
+	assert.Error(t, err)
+}
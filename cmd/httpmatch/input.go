@@ -0,0 +1,151 @@
+package main
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/codec"
+	"github.com/danapsimer/go-http-matchers/expr"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/har"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// input is a request, or the response to one, read from a file, stdin or an archive.
+type input struct {
+	// name identifies the input in the output, e.g. "orders.http#2".
+	name string
+	// value is the *http.Request or *http.Response the predicate is evaluated against.
+	value interface{}
+	// request is the request, or the request of the response.
+	request *http.Request
+}
+
+// loadPredicate compiles the predicate of the config file.  Files named *.json or *.yaml / *.yml hold a predicate
+// encoded by the codec package, any other file holds an expression.
+func loadPredicate(path string) (predicate.Predicate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p predicate.Predicate
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		p, err = codec.DecodeJSON(data)
+	case ".yaml", ".yml":
+		p, err = codec.DecodeYAML(data)
+	default:
+		p, err = expr.Compile(strings.TrimSpace(string(data)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// readRequests reads the raw HTTP/1.1 requests written one after the other in r.  Blank lines between the requests are
+// skipped.  The bodies of the requests are buffered, so requests with a body must have a Content-Length header or use
+// the chunked transfer encoding.
+func readRequests(name string, r io.Reader) ([]*input, error) {
+	reader := bufio.NewReader(r)
+	var inputs []*input
+	for {
+		if err := skipBlankLines(reader); err == io.EOF {
+			return inputs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%s: request %d: %w", name, len(inputs)+1, err)
+		}
+		if _, err := extractor.RequestBody(req); err != nil {
+			return nil, fmt.Errorf("%s: request %d: %w", name, len(inputs)+1, err)
+		}
+		inputs = append(inputs, &input{name: fmt.Sprintf("%s#%d", name, len(inputs)+1), value: req, request: req})
+	}
+}
+
+func skipBlankLines(reader *bufio.Reader) error {
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return err
+		}
+		if b[0] != '\r' && b[0] != '\n' {
+			return nil
+		}
+		reader.Discard(1)
+	}
+}
+
+// readArchive reads the entries of an archive as requests or, if responses is true, as responses.
+func readArchive(name string, r io.Reader, responses bool) ([]*input, error) {
+	archive, err := har.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	inputs := make([]*input, 0, len(archive.Log.Entries))
+	for i, entry := range archive.Log.Entries {
+		in := &input{name: fmt.Sprintf("%s#%d", name, i+1)}
+		if responses {
+			resp, err := entry.HTTPResponse()
+			if err != nil {
+				return nil, fmt.Errorf("%s: entry %d: %w", name, i+1, err)
+			}
+			in.value, in.request = resp, resp.Request
+		} else {
+			req, err := entry.HTTPRequest()
+			if err != nil {
+				return nil, fmt.Errorf("%s: entry %d: %w", name, i+1, err)
+			}
+			in.value, in.request = req, req
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, nil
+}
+
+// readInputs reads the inputs of the files, "-" meaning stdin.  Files are read as archives if archive is true.
+func readInputs(files []string, stdin io.Reader, archive, responses bool) ([]*input, error) {
+	var inputs []*input
+	for _, file := range files {
+		var r io.Reader = stdin
+		name := "stdin"
+		if file != "-" {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			r, name = f, file
+		}
+		var read []*input
+		var err error
+		if archive {
+			read, err = readArchive(name, r, responses)
+		} else {
+			read, err = readRequests(name, r)
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, read...)
+	}
+	return inputs, nil
+}
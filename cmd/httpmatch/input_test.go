@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadRequests(t *testing.T) {
+	raw := "\n\nGET /a HTTP/1.1\nHost: example.com\n\n" +
+		"PUT /b HTTP/1.1\r\nHost: example.com\r\nContent-Length: 5\r\n\r\nhello\r\n\r\n" +
+		"DELETE /c HTTP/1.1\r\nHost: example.com\r\nTransfer-Encoding: chunked\r\n\r\n3\r\nbye\r\n0\r\n\r\n"
+	inputs, err := readRequests("raw", strings.NewReader(raw))
+	assert.NoError(t, err)
+	assert.Len(t, inputs, 3)
+	assert.Equal(t, "raw#1", inputs[0].name)
+	assert.Equal(t, "/a", inputs[0].request.URL.Path)
+	assert.Equal(t, "example.com", inputs[0].request.Host)
+	data, _ := io.ReadAll(inputs[1].request.Body)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "bye", extractor.ExtractBody().Extract(inputs[2].value))
+
+	_, err = readRequests("raw", strings.NewReader("GET /a HTTP/1.1\r\nHost: example.com\r\n\r\nnot a request\r\n\r\n"))
+	assert.EqualError(t, err, "raw: request 2: malformed HTTP version \"request\"")
+}
+
+func TestReadArchive(t *testing.T) {
+	inputs, err := readInputs([]string{"../../har/testdata/sample.har"}, nil, true, true)
+	assert.NoError(t, err)
+	assert.Len(t, inputs, 3)
+	resp, ok := inputs[1].value.(*http.Response)
+	assert.True(t, ok)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.True(t, inputs[1].request == resp.Request)
+}
+
+func TestLoadPredicate(t *testing.T) {
+	for _, file := range []string{"testdata/get-order.expr", "testdata/post-order.yaml", "testdata/api-failures.json"} {
+		p, err := loadPredicate(file)
+		assert.NoError(t, err, file)
+		assert.NotNil(t, p, file)
+	}
+}
@@ -0,0 +1,225 @@
+// Command httpmatch evaluates a predicate against raw HTTP/1.1 requests, or the entries of HTTP Archives, and reports
+// which of them match and why the others do not:
+//
+//	httpmatch -e 'method == "POST" && path =~ "^/orders"' requests.http
+//	httpmatch -f rules.yaml -extract header:X-Id -extract xpath://order/id < request.http
+//	httpmatch -har -responses -f failures.json capture.har
+//
+// The predicate is either an expression, see package expr, given with -e or read from a file given with -f, or a
+// predicate encoded by package codec in a *.json, *.yaml or *.yml file given with -f.  Requests are read from the
+// files named on the command line or, if there are none or a file is named "-", from stdin.  A file can hold several
+// requests one after the other; requests with a body must have a Content-Length header.  With -har, the files are HTTP
+// Archives and either the requests or, with -responses, the responses of their entries are evaluated.
+//
+// Values are extracted from every request with -extract name or -extract name:arg, which can be repeated.  The names
+// are method, path, raw-path, uri, host, hostname, port, scheme, client-ip, body, content-type, content-length,
+// bearer and basic-user, and, with an argument, header, query, cookie, form, xpath, jsonpath, jsonpointer and
+// path-params, e.g. path-params:/orders/{id}.  The responses of archive entries also support status, status-class,
+// response-body, response-header, response-xpath and response-jsonpath.
+//
+// httpmatch exits with status 0 if every request matches the predicate, or with -any if at least one does, 1 if not,
+// and 2 if the predicate or a request could not be read or if no request was read at all.  Without a predicate, a
+// request matches if every value could be extracted from it.
+package main
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/expr"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// The exit statuses of httpmatch.
+const (
+	exitMatch   = 0
+	exitNoMatch = 1
+	exitError   = 2
+)
+
+// errUsage is returned by parseArgs when the command line can not be parsed.  The flag package has already reported the
+// error along with the usage.
+var errUsage = errors.New("invalid command line")
+
+// extractFlags collects the values of the repeatable -extract flag.
+type extractFlags []*extraction
+
+func (ef *extractFlags) String() string {
+	specs := make([]string, 0, len(*ef))
+	for _, x := range *ef {
+		specs = append(specs, x.spec)
+	}
+	return strings.Join(specs, ", ")
+}
+
+func (ef *extractFlags) Set(spec string) error {
+	x, err := parseExtraction(spec)
+	if err != nil {
+		return err
+	}
+	*ef = append(*ef, x)
+	return nil
+}
+
+// options holds the parsed command line.
+type options struct {
+	predicate predicate.Predicate
+	extract   extractFlags
+	archive   bool
+	responses bool
+	any       bool
+	quiet     bool
+	verbose   bool
+	files     []string
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs httpmatch with the arguments and returns its exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, err := parseArgs(args, stderr)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return exitMatch
+	case errors.Is(err, errUsage):
+		return exitError
+	case err != nil:
+		fmt.Fprintf(stderr, "httpmatch: %v\n", err)
+		return exitError
+	}
+	inputs, err := readInputs(opts.files, stdin, opts.archive, opts.responses)
+	if err != nil {
+		fmt.Fprintf(stderr, "httpmatch: %v\n", err)
+		return exitError
+	}
+	if len(inputs) == 0 {
+		fmt.Fprintln(stderr, "httpmatch: no requests read")
+		return exitError
+	}
+	if opts.quiet {
+		stdout = io.Discard
+	}
+	matched := 0
+	for _, in := range inputs {
+		if report(stdout, opts, in) {
+			matched++
+		}
+	}
+	if (opts.any && matched > 0) || (!opts.any && matched == len(inputs)) {
+		return exitMatch
+	}
+	return exitNoMatch
+}
+
+func parseArgs(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+	var expression, file string
+	flags := flag.NewFlagSet("httpmatch", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: httpmatch [-e expression | -f file] [-extract name[:arg]]... [-har [-responses]] "+
+			"[-any] [-q | -v] [file]...")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&expression, "e", "", "the `expression` of the predicate")
+	flags.StringVar(&file, "f", "", "read the predicate from the `file`, an expression or a *.json or *.yaml predicate")
+	flags.Var(&opts.extract, "extract", "extract a value from every request, e.g. header:X-Id (repeatable)")
+	flags.BoolVar(&opts.archive, "har", false, "read the requests from HTTP Archives")
+	flags.BoolVar(&opts.responses, "responses", false, "evaluate the responses of the archive entries")
+	flags.BoolVar(&opts.any, "any", false, "exit with status 0 if at least one request matches")
+	flags.BoolVar(&opts.quiet, "q", false, "print nothing, only set the exit status")
+	flags.BoolVar(&opts.verbose, "v", false, "print the evaluation of every node of the predicate")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil, err
+	} else if err != nil {
+		return nil, errUsage
+	}
+	switch {
+	case expression != "" && file != "":
+		return nil, errors.New("-e and -f can not be used together")
+	case expression != "":
+		p, err := expr.Compile(expression)
+		if err != nil {
+			return nil, err
+		}
+		opts.predicate = p
+	case file != "":
+		p, err := loadPredicate(file)
+		if err != nil {
+			return nil, err
+		}
+		opts.predicate = p
+	case len(opts.extract) == 0:
+		return nil, errors.New("a predicate (-e or -f) or a value to extract (-extract) is required")
+	}
+	if opts.responses && !opts.archive {
+		return nil, errors.New("-responses requires -har")
+	}
+	for _, x := range opts.extract {
+		if x.response && !opts.responses {
+			return nil, fmt.Errorf("extractor %q requires -responses", x.spec)
+		}
+	}
+	opts.files = flags.Args()
+	if len(opts.files) == 0 {
+		opts.files = []string{"-"}
+	}
+	return opts, nil
+}
+
+// report prints whether the input matches the predicate, why it does not and the values extracted from it, and
+// returns true if it matches.
+func report(w io.Writer, opts *options, in *input) bool {
+	summary := in.request.Method + " " + in.request.URL.RequestURI()
+	if resp, ok := in.value.(*http.Response); ok {
+		summary += fmt.Sprintf(" -> %d", resp.StatusCode)
+	}
+	matched := true
+	var details []string
+	if opts.predicate != nil {
+		result := predicate.Evaluate(opts.predicate, in.value)
+		matched = result.Accepted
+		status := "no match"
+		if matched {
+			status = "match"
+		}
+		summary = status + ": " + summary
+		switch {
+		case opts.verbose:
+			details = strings.Split(result.String(), "\n")
+		case !matched:
+			details = strings.Split(result.Explanation(), "\n")
+		}
+	}
+	for _, x := range opts.extract {
+		value, err := x.extract(in.value)
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", x.spec, err))
+			matched = matched && opts.predicate != nil
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s = %v", x.spec, value))
+	}
+	fmt.Fprintf(w, "%s: %s\n", in.name, summary)
+	for _, detail := range details {
+		fmt.Fprintf(w, "  %s\n", detail)
+	}
+	return matched
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func runWith(t *testing.T, stdin string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	status := run(args, strings.NewReader(stdin), stdout, stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRun_Expression(t *testing.T) {
+	status, stdout, stderr := runWith(t, "", "-e", `method == "GET" && path =~ "^/orders/"`,
+		"--extract", "header:X-Id", "--extract", "xpath://order/id", "testdata/orders.http")
+	assert.Equal(t, exitNoMatch, status)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `testdata/orders.http#1: match: GET /orders/42?expand=items
+  header:X-Id = 42
+  xpath://order/id: value missing: xpath //order/id selected nothing
+testdata/orders.http#2: no match: POST /orders
+  method: expected 'GET', got 'POST'
+  path: expected to match '^/orders/', got '/orders'
+  header:X-Id = 
+  xpath://order/id = 43
+`, stdout)
+
+	status, _, _ = runWith(t, "", "-any", "-e", `method == "GET"`, "testdata/orders.http")
+	assert.Equal(t, exitMatch, status)
+	status, _, _ = runWith(t, "", "-any", "-e", `method == "PUT"`, "testdata/orders.http")
+	assert.Equal(t, exitNoMatch, status)
+}
+
+func TestRun_File(t *testing.T) {
+	orders, err := os.ReadFile("testdata/orders.http")
+	assert.NoError(t, err)
+
+	status, stdout, _ := runWith(t, string(orders), "-f", "testdata/get-order.expr", "-q")
+	assert.Equal(t, exitNoMatch, status)
+	assert.Empty(t, stdout)
+
+	status, stdout, _ = runWith(t, string(orders), "-v", "-f", "testdata/post-order.yaml", "-")
+	assert.Equal(t, exitNoMatch, status)
+	assert.Contains(t, stdout, "stdin#2: match: POST /orders\n  [PASS] and\n")
+	assert.Contains(t, stdout, "[PASS] xpath //order/qty = '2'")
+
+	status, stdout, _ = runWith(t, "", "-har", "-responses", "-f", "testdata/api-failures.json", "-extract", "status",
+		"../../har/testdata/sample.har")
+	assert.Equal(t, exitNoMatch, status)
+	assert.Contains(t, stdout, "sample.har#2: match: POST /api/orders -> 503\n  status = 503\n")
+	assert.Contains(t, stdout, "sample.har#3: no match: POST /login -> 302\n")
+
+	status, _, _ = runWith(t, "", "-har", "-any", "-f", "testdata/post-order.yaml", "../../har/testdata/sample.har")
+	assert.Equal(t, exitNoMatch, status)
+	status, _, _ = runWith(t, "", "-har", "-any", "-e", `method == "POST"`, "../../har/testdata/sample.har")
+	assert.Equal(t, exitMatch, status)
+}
+
+func TestRun_Extract(t *testing.T) {
+	request := "GET /orders/42 HTTP/1.1\r\nHost: shop.example.com\r\nX-Id: 42\r\n\r\n"
+	status, stdout, _ := runWith(t, request, "-extract", "path-params:/orders/{id}", "-extract", "method")
+	assert.Equal(t, exitMatch, status)
+	assert.Equal(t, "stdin#1: GET /orders/42\n  path-params:/orders/{id} = map[id:42]\n  method = GET\n", stdout)
+
+	status, _, _ = runWith(t, request, "-extract", "jsonpath:$.id")
+	assert.Equal(t, exitNoMatch, status)
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := map[string][]string{
+		"httpmatch: 1:10: expected string":          {"-e", "method =="},
+		"httpmatch: -e and -f can not be used":      {"-e", "true", "-f", "testdata/get-order.expr"},
+		"httpmatch: a predicate (-e or -f) or":      {},
+		"httpmatch: -responses requires -har":       {"-e", "true", "-responses"},
+		"httpmatch: open testdata/missing.http":     {"-e", "true", "testdata/missing.http"},
+		"httpmatch: open testdata/missing.yaml":     {"-f", "testdata/missing.yaml"},
+		`unknown extractor "nope" in "nope"`:        {"-extract", "nope"},
+		`extractor "header" requires an argument`:   {"-extract", "header"},
+		`extractor "method" does not take an`:       {"-extract", "method:x"},
+		`invalid extractor "xpath:[": `:             {"-extract", "xpath:["},
+		"httpmatch: stdin: request 1: malformed":    {"-e", "true"},
+		"httpmatch: testdata/orders.http: har: inv": {"-har", "-e", "true", "testdata/orders.http"},
+		"flag provided but not defined: -x":         {"-x"},
+		`extractor "status" requires -responses`:    {"-har", "-extract", "status"},
+		`"response-header:X" requires -responses`:   {"-e", "true", "-extract", "response-header:X"},
+	}
+	for expected, args := range tests {
+		status, _, stderr := runWith(t, "nonsense\r\n\r\n", args...)
+		assert.Equal(t, exitError, status, "%v", args)
+		assert.Contains(t, stderr, expected, "%v", args)
+	}
+
+	for _, stdin := range []string{"", "\r\n\r\n"} {
+		status, stdout, stderr := runWith(t, stdin, "-e", "true")
+		assert.Equal(t, exitError, status)
+		assert.Empty(t, stdout)
+		assert.Equal(t, "httpmatch: no requests read\n", stderr)
+	}
+
+	status, _, stderr := runWith(t, "", "-h")
+	assert.Equal(t, exitMatch, status)
+	assert.Contains(t, stderr, "usage: httpmatch")
+}
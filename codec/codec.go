@@ -0,0 +1,272 @@
+// Package codec marshals predicate trees to and from JSON and YAML so that they can be defined declaratively, e.g. in
+// the stub definitions of a mock server or the route definitions of a proxy.  Each predicate is encoded as a Node
+// holding the name it is registered under, the arguments that were passed to its constructor and, for predicates like
+// And, Or and Not, the predicates it combines:
+//
+//	{"type": "And", "predicates": [
+//	  {"type": "MethodIs", "args": ["GET"]},
+//	  {"type": "HeaderEquals", "args": ["X-Tenant", "a"]}
+//	]}
+//
+// The first Node nested in an ExtractedValueAccepted predicate is its extractor, encoded the same way:
+//
+//	{"type": "ExtractedValueAccepted", "predicates": [
+//	  {"type": "AsInt", "predicates": [{"type": "ExtractQueryParameter", "args": ["limit"]}]},
+//	  {"type": "GreaterThan", "args": [100]}
+//	]}
+//
+// Regular expressions are encoded as strings and times as strings in RFC 3339 format.  Predicates are rebuilt from
+// their Node by the constructor registered under the Node's type in a Registry.  All of the predicates in the
+// predicate package and the extractors in the extractor package are registered in the DefaultRegistry; third party
+// predicates and extractors can be registered under their own names with Register and RegisterExtractor.
+package codec
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"time"
+)
+
+// Node is the serialized form of a predicate.
+type Node struct {
+	Type       string        `json:"type" yaml:"type"`
+	Args       []interface{} `json:"args,omitempty" yaml:"args,omitempty"`
+	Predicates []*Node       `json:"predicates,omitempty" yaml:"predicates,omitempty"`
+}
+
+// Encode converts the predicate to a Node using the DefaultRegistry.
+func Encode(p predicate.Predicate) (*Node, error) {
+	return DefaultRegistry.Encode(p)
+}
+
+// Decode builds the predicate described by the Node using the DefaultRegistry.
+func Decode(node *Node) (predicate.Predicate, error) {
+	return DefaultRegistry.Decode(node)
+}
+
+// EncodeJSON encodes the predicate as JSON using the DefaultRegistry.
+func EncodeJSON(p predicate.Predicate) ([]byte, error) {
+	return DefaultRegistry.EncodeJSON(p)
+}
+
+// DecodeJSON decodes a predicate from JSON using the DefaultRegistry.
+func DecodeJSON(data []byte) (predicate.Predicate, error) {
+	return DefaultRegistry.DecodeJSON(data)
+}
+
+// EncodeYAML encodes the predicate as YAML using the DefaultRegistry.
+func EncodeYAML(p predicate.Predicate) ([]byte, error) {
+	return DefaultRegistry.EncodeYAML(p)
+}
+
+// DecodeYAML decodes a predicate from YAML using the DefaultRegistry.
+func DecodeYAML(data []byte) (predicate.Predicate, error) {
+	return DefaultRegistry.DecodeYAML(data)
+}
+
+// Encode converts the predicate to a Node.  The predicate, and every predicate and extractor nested in it, must
+// implement predicate.Describer and be described by a name that is registered with the registry and by arguments that
+// are not extractor.Opaque.
+func (r *Registry) Encode(p predicate.Predicate) (*Node, error) {
+	return r.encode(extractor.Describe(p))
+}
+
+// extractedValueAccepted is the type of the Node of an ExtractedValueAccepted predicate.  Its first nested Node is
+// the extractor and the second the predicate applied to the extracted value.
+const extractedValueAccepted = "ExtractedValueAccepted"
+
+func (r *Registry) encode(d predicate.Description) (*Node, error) {
+	if d.Name == extractedValueAccepted && len(d.Children) == 2 {
+		e, err := r.encodeExtractor(d.Children[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := r.encode(d.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		return &Node{Type: d.Name, Predicates: []*Node{e, p}}, nil
+	}
+	if _, ok := r.lookup(d.Name); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPredicate, d.Name)
+	}
+	node, err := encodeArgs(d)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range d.Children {
+		childNode, err := r.encode(child)
+		if err != nil {
+			return nil, err
+		}
+		node.Predicates = append(node.Predicates, childNode)
+	}
+	return node, nil
+}
+
+func (r *Registry) encodeExtractor(d extractor.Description) (*Node, error) {
+	if _, ok := r.lookupExtractor(d.Name); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExtractor, d.Name)
+	}
+	node, err := encodeArgs(d)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range d.Children {
+		childNode, err := r.encodeExtractor(child)
+		if err != nil {
+			return nil, err
+		}
+		node.Predicates = append(node.Predicates, childNode)
+	}
+	return node, nil
+}
+
+// encodeArgs returns a Node of the type and with the arguments of the description.
+func encodeArgs(d extractor.Description) (*Node, error) {
+	node := &Node{Type: d.Name}
+	for _, arg := range d.Args {
+		switch a := arg.(type) {
+		case *regexp.Regexp:
+			arg = a.String()
+		case time.Time:
+			arg = a.Format(time.RFC3339Nano)
+		case extractor.Opaque:
+			return nil, fmt.Errorf("%w: %v", ErrUnencodable, d)
+		}
+		node.Args = append(node.Args, arg)
+	}
+	return node, nil
+}
+
+// Decode builds the predicate described by the Node.
+func (r *Registry) Decode(node *Node) (predicate.Predicate, error) {
+	if node == nil {
+		return nil, fmt.Errorf("%w: missing predicate", ErrInvalidNode)
+	}
+	if node.Type == extractedValueAccepted {
+		return r.decodeExtractedValue(node)
+	}
+	constructor, ok := r.lookup(node.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPredicate, node.Type)
+	}
+	predicates := make([]predicate.Predicate, 0, len(node.Predicates))
+	for _, child := range node.Predicates {
+		p, err := r.Decode(child)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+	p, err := construct(func() (predicate.Predicate, error) {
+		return constructor(node.Args, predicates)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", node.Type, err)
+	}
+	return p, nil
+}
+
+func (r *Registry) decodeExtractedValue(node *Node) (predicate.Predicate, error) {
+	if len(node.Args) != 0 || len(node.Predicates) != 2 {
+		return nil, fmt.Errorf("%s: %w: expected an extractor and a predicate", node.Type, ErrInvalidNode)
+	}
+	e, err := r.decodeExtractor(node.Predicates[0])
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.Decode(node.Predicates[1])
+	if err != nil {
+		return nil, err
+	}
+	return predicate.ExtractedValueAccepted(e, p), nil
+}
+
+func (r *Registry) decodeExtractor(node *Node) (extractor.Extractor, error) {
+	if node == nil {
+		return nil, fmt.Errorf("%w: missing extractor", ErrInvalidNode)
+	}
+	constructor, ok := r.lookupExtractor(node.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExtractor, node.Type)
+	}
+	extractors := make([]extractor.Extractor, 0, len(node.Predicates))
+	for _, child := range node.Predicates {
+		e, err := r.decodeExtractor(child)
+		if err != nil {
+			return nil, err
+		}
+		extractors = append(extractors, e)
+	}
+	e, err := construct(func() (extractor.Extractor, error) {
+		return constructor(node.Args, extractors)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", node.Type, err)
+	}
+	return e, nil
+}
+
+// construct calls the constructor, converting a panic, like the one raised by an invalid XPath or JSONPath
+// expression, into an error.
+func construct[T any](constructor func() (T, error)) (t T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			t, err = zero, fmt.Errorf("%w: %v", ErrInvalidNode, r)
+		}
+	}()
+	return constructor()
+}
+
+// EncodeJSON encodes the predicate as JSON.
+func (r *Registry) EncodeJSON(p predicate.Predicate) ([]byte, error) {
+	node, err := r.Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node)
+}
+
+// DecodeJSON decodes a predicate from JSON.
+func (r *Registry) DecodeJSON(data []byte) (predicate.Predicate, error) {
+	node := &Node{}
+	if err := json.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	return r.Decode(node)
+}
+
+// EncodeYAML encodes the predicate as YAML.
+func (r *Registry) EncodeYAML(p predicate.Predicate) ([]byte, error) {
+	node, err := r.Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(node)
+}
+
+// DecodeYAML decodes a predicate from YAML.
+func (r *Registry) DecodeYAML(data []byte) (predicate.Predicate, error) {
+	node := &Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	return r.Decode(node)
+}
@@ -0,0 +1,25 @@
+package codec_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/codec"
+	"net/http"
+)
+
+func ExampleDecodeJSON() {
+	p, err := codec.DecodeJSON([]byte(`{"type": "And", "predicates": [
+		{"type": "MethodIs", "args": ["GET"]},
+		{"type": "HeaderEquals", "args": ["X-Tenant", "a"]}
+	]}`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	req, _ := http.NewRequest("GET", "http://foo.com/test", nil)
+	req.Header.Add("X-Tenant", "a")
+	fmt.Println(p)
+	fmt.Println(p.Accept(req))
+	// Output:
+	// And(MethodIs("GET"), HeaderEquals("X-Tenant", "a"))
+	// true
+}
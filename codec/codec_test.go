@@ -0,0 +1,316 @@
+package codec_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/codec"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var roundTripTests = []predicate.Predicate{
+	predicate.True(),
+	predicate.False(),
+	predicate.MethodIs("GET"),
+	predicate.And(predicate.MethodIs("POST"), predicate.Not(predicate.PathEquals("/a"))),
+	predicate.Or(predicate.PathStartsWith("/api/"), predicate.PathMatches(regexp.MustCompile("^/v[0-9]+/"))),
+	predicate.PathTemplate("/users/{id}/orders/{orderId:[0-9]+}"),
+	predicate.PathParamEquals("/users/{id}", "id", "42"),
+	predicate.SchemeEquals("https"),
+	predicate.SchemeMatches(regexp.MustCompile("^wss?$")),
+	predicate.SchemeIn("http", "https"),
+	predicate.HostnameEquals("example.com"),
+	predicate.HostnameMatches(regexp.MustCompile(`\.example\.com$`)),
+	predicate.HostnameIn("example.com", "*.example.com"),
+	predicate.HostIn("bücher.example", "*.example.com"),
+	predicate.PortEquals("8080"),
+	predicate.PortMatches(regexp.MustCompile("^80")),
+	predicate.PortIn("80", "443"),
+	predicate.RawPathEquals("/a%2Fb"),
+	predicate.RawPathMatches(regexp.MustCompile("%2F")),
+	predicate.RawPathIn("/a", "/b"),
+	predicate.EscapedPathEquals("/a%2Fb"),
+	predicate.EscapedPathMatches(regexp.MustCompile("%2F")),
+	predicate.EscapedPathIn("/a", "/b"),
+	predicate.RequestURIEquals("/a?b=c"),
+	predicate.RequestURIStartsWith("/a"),
+	predicate.RequestURIMatches(regexp.MustCompile("b=c$")),
+	predicate.HeaderEquals("X-Tenant", "a"),
+	predicate.HeaderEqualsIgnoreCase("X-Tenant", "a"),
+	predicate.HeaderContains("X-Tenant", "a"),
+	predicate.HeaderContainsIgnoreCase("X-Tenant", "a"),
+	predicate.HeaderStartsWith("X-Tenant", "a"),
+	predicate.HeaderMatches("X-Tenant", regexp.MustCompile("^[a-z]$")),
+	predicate.HeaderExists("X-Tenant"),
+	predicate.HeaderAbsent("X-Tenant"),
+	predicate.ClientIPInCIDR("10.0.0.0/8", "2001:db8::/32"),
+	predicate.ClientIPIn(extractor.MustIPSet("192.168.1.10", "10.0.0.0/8")),
+	predicate.ClientIPIsPrivate(),
+	predicate.ClientIPIsLoopback(),
+	predicate.WithTrustedProxies(predicate.And(predicate.SchemeEquals("https"), predicate.PortIn("443")), "10.0.0.0/8"),
+	predicate.ContentTypeIs("application/*+json"),
+	predicate.AcceptsMediaType("text/html"),
+	predicate.AcceptsLanguage("en-US"),
+	predicate.AcceptsEncoding("gzip"),
+	predicate.BasicAuthUser("admin"),
+	predicate.BasicAuth("admin", "secret"),
+	predicate.BearerTokenEquals("abc"),
+	predicate.JWTClaimEquals("sub", "u1"),
+	predicate.JWTHasScope("read"),
+	predicate.JWTNotExpired(nil),
+	predicate.QueryParamGreaterThan("limit", 10),
+	predicate.QueryParamLessThan("limit", 0.5),
+	predicate.QueryParamBetween("limit", 1, 100),
+	predicate.ContentLengthGreaterThan(0),
+	predicate.ContentLengthLessThan(1024),
+	predicate.ContentLengthBetween(1, 1024),
+	predicate.AnyValue(predicate.GreaterThan(100)),
+	predicate.AllValues(predicate.Not(predicate.NumericEquals(0))),
+	predicate.ValueCount(2),
+	predicate.ContainsAll("read", "write"),
+	predicate.GreaterThan(100),
+	predicate.LessThan(0.5),
+	predicate.Between(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 23, 59, 59, 5e8, time.UTC)),
+	predicate.NumericEquals(200),
+	predicate.StatusIs(200),
+	predicate.StatusIn("2xx", "304"),
+	predicate.ResponseHeaderEquals("Content-Type", "application/json"),
+	predicate.ResponseHeaderContains("Cache-Control", "no-store"),
+	predicate.ResponseHeaderMatches("ETag", regexp.MustCompile(`^"`)),
+	predicate.ResponseTrailerEquals("Grpc-Status", "0"),
+	predicate.ResponseBodyXPathEquals("/order/id", "1"),
+	predicate.ResponseBodyXPathMatches("/order/id", regexp.MustCompile("^[0-9]+$")),
+	predicate.ResponseBodyJSONPathEquals("$.total", 1.5),
+	predicate.And(predicate.RequestMatches(predicate.MethodIs("GET")), predicate.StatusIn("5xx")),
+	predicate.CookieEquals("session", "a"),
+	predicate.CookieMatches("session", regexp.MustCompile("^a")),
+	predicate.CookieStartsWith("session", "a"),
+	predicate.CookieExists("session"),
+	predicate.QueryParamEquals("q", "a"),
+	predicate.QueryParamEqualsIgnoreCase("q", "a"),
+	predicate.QueryParamContains("q", "a"),
+	predicate.QueryParamContainsIgnoreCase("q", "a"),
+	predicate.QueryParamStartsWith("q", "a"),
+	predicate.QueryParamMatches("q", regexp.MustCompile("a+")),
+	predicate.QueryParamExists("q"),
+	predicate.QueryParamAbsent("q"),
+	predicate.FormFieldEquals("f", "a"),
+	predicate.FormFieldEqualsIgnoreCase("f", "a"),
+	predicate.FormFieldContains("f", "a"),
+	predicate.FormFieldMatches("f", regexp.MustCompile("a+")),
+	predicate.FormFieldStartsWith("f", "a"),
+	predicate.FormFieldExists("f"),
+	predicate.MultipartFileNameEquals("f", "a.txt"),
+	predicate.MultipartFileNameMatches("f", regexp.MustCompile(`\.txt$`)),
+	predicate.MultipartContentTypeEquals("f", "text/plain"),
+	predicate.MultipartContentTypeMatches("f", regexp.MustCompile("^text/")),
+	predicate.BodyXPathEquals("/a/b", "c"),
+	predicate.BodyXPathEqualsIgnoreCase("/a/b", "c"),
+	predicate.BodyXPathMatches("/a/b", regexp.MustCompile("c")),
+	predicate.BodyXPathExists("/a/b"),
+	predicate.BodyJSONPathEquals("$.a", "b"),
+	predicate.BodyJSONPathEqualsIgnoreCase("$.a", "b"),
+	predicate.BodyJSONPathMatches("$.a", regexp.MustCompile("b")),
+	predicate.BodyJSONPathContains("$.a", "b"),
+	predicate.BodyJSONPathExists("$.a"),
+	predicate.BodyJSONPointerEquals("/a", "b"),
+	predicate.ExtractedValueAccepted(extractor.AsInt(extractor.ExtractQueryParameter("limit")),
+		predicate.GreaterThan(100)),
+	predicate.ExtractedValueAccepted(extractor.ExtractHeader("X-Host"), predicate.HostIn("*.example.com")),
+	predicate.ExtractedValueAccepted(extractor.AsTime(extractor.ExtractHeader("Date"), time.RFC1123),
+		predicate.LessThan(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))),
+	predicate.ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractMethod()),
+		predicate.StringIn("GET", "HEAD")),
+	predicate.ExtractedValueAccepted(extractor.ExtractQueryParameterValues("tag"),
+		predicate.AnyValue(predicate.StringMatches(regexp.MustCompile("^a")))),
+	predicate.ExtractedValueAccepted(extractor.ExtractPathParameter("/users/{id}", "id"), predicate.StringEquals("1")),
+	predicate.ExtractedValueAccepted(extractor.ExtractPath(), predicate.StringMatchesTemplate("/users/{id}")),
+	predicate.ExtractedValueAccepted(extractor.ExtractPathElementByIndex(1), predicate.StringEndsWith("s")),
+	predicate.ExtractedValueAccepted(extractor.ExtractClientIP("10.0.0.0/8"), predicate.IPInCIDR("203.0.113.0/24")),
+	predicate.ExtractedValueAccepted(extractor.ExtractOptionalCookie("session"), predicate.IsAbsent()),
+	predicate.ExtractedValueAccepted(extractor.ExtractJSONPath("$.tags"), predicate.JSONContains("a")),
+	predicate.ExtractedValueAccepted(extractor.ExtractJWTClaim("sub"), predicate.JSONEqualsIgnoreCase("u1")),
+	predicate.ExtractedValueAccepted(extractor.ExtractWeightedHeader("Accept"), predicate.IncludesMediaType("text/html")),
+	predicate.ExtractedValueAccepted(extractor.ExtractJWT(), predicate.TokenNotExpired(nil)),
+}
+
+func TestRoundTrip_JSON(t *testing.T) {
+	for _, p := range roundTripTests {
+		data, err := EncodeJSON(p)
+		if assert.NoError(t, err, "%v", p) {
+			decoded, err := DecodeJSON(data)
+			if assert.NoError(t, err, string(data)) {
+				assert.Equal(t, extractor.Describe(p), extractor.Describe(decoded))
+			}
+		}
+	}
+}
+
+func TestRoundTrip_YAML(t *testing.T) {
+	for _, p := range roundTripTests {
+		data, err := EncodeYAML(p)
+		if assert.NoError(t, err, "%v", p) {
+			decoded, err := DecodeYAML(data)
+			if assert.NoError(t, err, string(data)) {
+				assert.Equal(t, extractor.Describe(p), extractor.Describe(decoded))
+			}
+		}
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	data, err := EncodeJSON(predicate.And(predicate.MethodIs("GET"), predicate.HeaderEquals("X-Tenant", "a")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "And", "predicates": [
+		{"type": "MethodIs", "args": ["GET"]},
+		{"type": "HeaderEquals", "args": ["X-Tenant", "a"]}
+	]}`, string(data))
+}
+
+func TestDecodeYAML(t *testing.T) {
+	p, err := DecodeYAML([]byte(`
+type: And
+predicates:
+  - type: MethodIs
+    args: [GET]
+  - type: PathMatches
+    args: ["^/api/"]
+`))
+	if assert.NoError(t, err) {
+		req, _ := http.NewRequest("GET", "http://foo.com/api/foo", nil)
+		assert.True(t, p.Accept(req))
+		req, _ = http.NewRequest("GET", "http://foo.com/v2/foo", nil)
+		assert.False(t, p.Accept(req))
+	}
+}
+
+func TestEncodeJSON_Comparisons(t *testing.T) {
+	data, err := EncodeJSON(predicate.Or(predicate.GreaterThan(100),
+		predicate.LessThan(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "Or", "predicates": [
+		{"type": "GreaterThan", "args": [100]},
+		{"type": "LessThan", "args": ["2024-01-01T00:00:00Z"]}
+	]}`, string(data))
+}
+
+func TestEncodeJSON_ExtractedValueAccepted(t *testing.T) {
+	p := predicate.ExtractedValueAccepted(extractor.AsInt(extractor.ExtractQueryParameter("limit")),
+		predicate.GreaterThan(100))
+	data, err := EncodeJSON(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "ExtractedValueAccepted", "predicates": [
+		{"type": "AsInt", "predicates": [{"type": "ExtractQueryParameter", "args": ["limit"]}]},
+		{"type": "GreaterThan", "args": [100]}
+	]}`, string(data))
+
+	decoded, err := DecodeJSON(data)
+	if assert.NoError(t, err) {
+		req, _ := http.NewRequest("GET", "http://foo.com/orders?limit=150", nil)
+		assert.True(t, decoded.Accept(req))
+		req, _ = http.NewRequest("GET", "http://foo.com/orders?limit=50", nil)
+		assert.False(t, decoded.Accept(req))
+	}
+}
+
+func TestEncode_Unknown(t *testing.T) {
+	_, err := EncodeJSON(predicate.And(predicate.MethodIs("GET"), predicate.PredicateFunc(func(interface{}) bool {
+		return true
+	})))
+	assert.True(t, errors.Is(err, ErrUnknownPredicate))
+	assert.Contains(t, err.Error(), "predicate.PredicateFunc")
+}
+
+func TestRoundTrip_WithTrustedProxies(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://foo.com/", nil)
+	req.RemoteAddr = "10.1.1.1:5555"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Port", "443")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	tests := []predicate.Predicate{
+		predicate.SchemeEquals("https"),
+		predicate.PortEquals("443"),
+		predicate.ClientIPInCIDR("203.0.113.0/24"),
+	}
+	for _, p := range tests {
+		p = predicate.WithTrustedProxies(p, "10.0.0.0/8")
+		data, err := EncodeJSON(p)
+		if assert.NoError(t, err, "%v", p) {
+			decoded, err := DecodeJSON(data)
+			if assert.NoError(t, err, string(data)) {
+				assert.True(t, p.Accept(req), "%v", p)
+				assert.True(t, decoded.Accept(req), string(data))
+			}
+		}
+	}
+}
+
+func TestEncode_Unencodable(t *testing.T) {
+	_, err := EncodeJSON(predicate.And(predicate.MethodIs("GET"), predicate.JWTClaimEquals("sub", "u1", []byte("secret"))))
+	assert.True(t, errors.Is(err, ErrUnencodable), "%v", err)
+	assert.Contains(t, err.Error(), `JWTClaimEquals("sub", "u1", <keys>)`)
+	assert.NotContains(t, err.Error(), "secret")
+
+	_, err = EncodeJSON(predicate.ExtractedValueAccepted(extractor.ExtractJWTClaim("sub", []byte("secret")),
+		predicate.JSONEquals("u1")))
+	assert.True(t, errors.Is(err, ErrUnencodable), "%v", err)
+}
+
+func TestEncode_UnknownExtractor(t *testing.T) {
+	e := extractor.ExtractorFunc(func(interface{}) interface{} { return nil })
+	_, err := EncodeJSON(predicate.ExtractedValueAccepted(e, predicate.True()))
+	assert.True(t, errors.Is(err, ErrUnknownExtractor), "%v", err)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		JSON  string
+		Error error
+		Text  string
+	}{
+		{`{"type": "Snafu"}`, ErrUnknownPredicate, `unknown predicate: "Snafu"`},
+		{`{"type": "And", "predicates": [{"type": "Snafu"}]}`, ErrUnknownPredicate, `"Snafu"`},
+		{`{"type": "MethodIs"}`, ErrInvalidNode, "MethodIs: invalid predicate definition: expected 1 arguments, got 0"},
+		{`{"type": "MethodIs", "args": [5]}`, ErrInvalidNode, "argument 1 must be a string"},
+		{`{"type": "PathMatches", "args": ["("]}`, ErrInvalidNode, "argument 1"},
+		{`{"type": "Not", "predicates": []}`, ErrInvalidNode, "expected 1 predicate"},
+		{`{"type": "True", "predicates": [{"type": "False"}]}`, ErrInvalidNode, "does not take nested predicates"},
+		{`{"type": "BodyJSONPathExists", "args": ["a.b"]}`, ErrInvalidNode, "must start with '$'"},
+		{`{"type": "PathTemplate", "args": ["/users/{id"]}`, ErrInvalidNode, "unterminated '{'"},
+		{`{"type": "ExtractedValueAccepted", "predicates": [{"type": "True"}]}`, ErrInvalidNode,
+			"expected an extractor and a predicate"},
+		{`{"type": "ExtractedValueAccepted", "predicates": [{"type": "True"}, {"type": "True"}]}`,
+			ErrUnknownExtractor, `"True"`},
+		{`{"type": "ExtractedValueAccepted", "predicates": [{"type": "AsInt"}, {"type": "True"}]}`, ErrInvalidNode,
+			"AsInt: invalid predicate definition: expected 1 extractor"},
+		{`{"type": "ExtractedValueAccepted", "predicates": [{"type": "ExtractXPathString", "args": ["/a["]},
+			{"type": "True"}]}`, ErrInvalidNode, "ExtractXPathString"},
+		{`{"type": "AnyValue"}`, ErrInvalidNode, "expected 1 predicate"},
+		{`{"type": "ValueCount", "args": ["2"]}`, ErrInvalidNode, "must be an integer"},
+		{`{"type": "GreaterThan", "args": ["yesterday"]}`, ErrInvalidNode, "argument 1"},
+		{`{"type": "Between", "args": [1]}`, ErrInvalidNode, "expected 2 arguments"},
+		{`{"type": "ClientIPIn", "args": ["10.0.0.0/33"]}`, ErrInvalidNode, "ClientIPIn"},
+		{`{"type": "WithTrustedProxies", "args": ["10.0.0.0/33"], "predicates": [{"type": "True"}]}`, ErrInvalidNode,
+			"WithTrustedProxies"},
+	}
+	for _, tst := range tests {
+		_, err := DecodeJSON([]byte(tst.JSON))
+		if assert.Error(t, err, tst.JSON) {
+			assert.True(t, errors.Is(err, tst.Error), err.Error())
+			assert.True(t, strings.Contains(err.Error(), tst.Text), err.Error())
+		}
+	}
+}
+
+func TestDecodeJSON_BodyJSONPathEquals(t *testing.T) {
+	p, err := DecodeYAML([]byte(`{type: BodyJSONPathEquals, args: ["$.qty", 3]}`))
+	if assert.NoError(t, err) {
+		req, _ := http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(`{"qty": 3}`))
+		assert.True(t, p.Accept(req))
+	}
+}
@@ -0,0 +1,202 @@
+package codec
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+)
+
+// builtinExtractors are the extractors of the extractor package.  The ones that verify JWTs are built without keys,
+// since extractors described with keys can not be encoded.
+var builtinExtractors = map[string]ExtractorConstructor{
+	"IdentityExtractor":  noArgsExtractor(extractor.IdentityExtractor),
+	"UpperCaseExtractor": decorator(extractor.UpperCaseExtractor),
+	"AsInt":              decorator(extractor.AsInt),
+	"AsFloat":            decorator(extractor.AsFloat),
+	"AsDuration":         decorator(extractor.AsDuration),
+	"AsTime":             decoratorWithStrings(extractor.AsTime),
+
+	"ExtractMethod":             noArgsExtractor(extractor.ExtractMethod),
+	"ExtractPath":               noArgsExtractor(extractor.ExtractPath),
+	"ExtractRequestURI":         noArgsExtractor(extractor.ExtractRequestURI),
+	"ExtractHost":               noArgsExtractor(extractor.ExtractHost),
+	"ExtractHostname":           noArgsExtractor(extractor.ExtractHostname),
+	"ExtractScheme":             manyStringsExtractor(extractor.ExtractScheme),
+	"ExtractPort":               manyStringsExtractor(extractor.ExtractPort),
+	"ExtractRawPath":            noArgsExtractor(extractor.ExtractRawPath),
+	"ExtractEscapedPath":        noArgsExtractor(extractor.ExtractEscapedPath),
+	"ExtractURLUser":            noArgsExtractor(extractor.ExtractURLUser),
+	"ExtractPathElementByIndex": oneIntExtractor(extractor.ExtractPathElementByIndex),
+	"ExtractPathParameters":     oneStringExtractor(extractor.ExtractPathParameters),
+	"ExtractPathParameter":      twoStringsExtractor(extractor.ExtractPathParameter),
+	"ExtractClientIP":           manyStringsExtractor(extractor.ExtractClientIP),
+
+	"ExtractHeader":                 oneStringExtractor(extractor.ExtractHeader),
+	"ExtractOptionalHeader":         oneStringExtractor(extractor.ExtractOptionalHeader),
+	"ExtractHeaderValues":           oneStringExtractor(extractor.ExtractHeaderValues),
+	"ExtractWeightedHeader":         oneStringExtractor(extractor.ExtractWeightedHeader),
+	"ExtractContentType":            noArgsExtractor(extractor.ExtractContentType),
+	"ExtractContentLength":          noArgsExtractor(extractor.ExtractContentLength),
+	"ExtractQueryParameter":         oneStringExtractor(extractor.ExtractQueryParameter),
+	"ExtractOptionalQueryParameter": oneStringExtractor(extractor.ExtractOptionalQueryParameter),
+	"ExtractQueryParameterValues":   oneStringExtractor(extractor.ExtractQueryParameterValues),
+	"ExtractCookie":                 oneStringExtractor(extractor.ExtractCookie),
+	"ExtractOptionalCookie":         oneStringExtractor(extractor.ExtractOptionalCookie),
+	"ExtractCookieAttributes":       oneStringExtractor(extractor.ExtractCookieAttributes),
+
+	"ExtractBasicAuthUser":     noArgsExtractor(extractor.ExtractBasicAuthUser),
+	"ExtractBasicAuthPassword": noArgsExtractor(extractor.ExtractBasicAuthPassword),
+	"ExtractBearerToken":       noArgsExtractor(extractor.ExtractBearerToken),
+	"ExtractJWT": noArgsExtractor(func() extractor.Extractor {
+		return extractor.ExtractJWT()
+	}),
+	"ExtractJWTClaim": oneStringExtractor(func(name string) extractor.Extractor {
+		return extractor.ExtractJWTClaim(name)
+	}),
+	"ExtractJWTHeader": oneStringExtractor(func(name string) extractor.Extractor {
+		return extractor.ExtractJWTHeader(name)
+	}),
+	"ExtractJWTScopes": noArgsExtractor(func() extractor.Extractor {
+		return extractor.ExtractJWTScopes()
+	}),
+
+	"ExtractBody":                 noArgsExtractor(extractor.ExtractBody),
+	"ExtractXPathString":          oneStringExtractor(extractor.ExtractXPathString),
+	"ExtractOptionalXPathString":  oneStringExtractor(extractor.ExtractOptionalXPathString),
+	"ExtractJSONPath":             oneStringExtractor(extractor.ExtractJSONPath),
+	"ExtractJSONPathAll":          oneStringExtractor(extractor.ExtractJSONPathAll),
+	"ExtractJSONPointer":          oneStringExtractor(extractor.ExtractJSONPointer),
+	"ExtractFormField":            oneStringExtractor(extractor.ExtractFormField),
+	"ExtractOptionalFormField":    oneStringExtractor(extractor.ExtractOptionalFormField),
+	"ExtractMultipartFileName":    oneStringExtractor(extractor.ExtractMultipartFileName),
+	"ExtractMultipartContentType": oneStringExtractor(extractor.ExtractMultipartContentType),
+	"ExtractMultipartSize":        oneStringExtractor(extractor.ExtractMultipartSize),
+
+	"ExtractStatusCode":           noArgsExtractor(extractor.ExtractStatusCode),
+	"ExtractStatusClass":          noArgsExtractor(extractor.ExtractStatusClass),
+	"ExtractResponseHeader":       oneStringExtractor(extractor.ExtractResponseHeader),
+	"ExtractResponseHeaderValues": oneStringExtractor(extractor.ExtractResponseHeaderValues),
+	"ExtractTrailer":              oneStringExtractor(extractor.ExtractTrailer),
+	"ExtractResponseBody":         noArgsExtractor(extractor.ExtractResponseBody),
+	"ExtractResponseXPathString":  oneStringExtractor(extractor.ExtractResponseXPathString),
+	"ExtractResponseJSONPath":     oneStringExtractor(extractor.ExtractResponseJSONPath),
+}
+
+func extractorLeaf(args Args, extractors []extractor.Extractor, n int) error {
+	if len(extractors) != 0 {
+		return fmt.Errorf("%w: does not take nested extractors", ErrInvalidNode)
+	}
+	return args.Expect(n)
+}
+
+func stringArgs(args Args) ([]string, error) {
+	values := make([]string, 0, len(args))
+	for i := range args {
+		s, err := args.String(i)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+func noArgsExtractor(f func() extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := extractorLeaf(args, extractors, 0); err != nil {
+			return nil, err
+		}
+		return f(), nil
+	}
+}
+
+func oneStringExtractor(f func(string) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := extractorLeaf(args, extractors, 1); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(s), nil
+	}
+}
+
+func twoStringsExtractor(f func(string, string) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := extractorLeaf(args, extractors, 2); err != nil {
+			return nil, err
+		}
+		s1, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		s2, err := args.String(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(s1, s2), nil
+	}
+}
+
+func manyStringsExtractor(f func(...string) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := extractorLeaf(args, extractors, len(args)); err != nil {
+			return nil, err
+		}
+		values, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return f(values...), nil
+	}
+}
+
+func oneIntExtractor(f func(int) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := extractorLeaf(args, extractors, 1); err != nil {
+			return nil, err
+		}
+		n, err := args.Int(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(n), nil
+	}
+}
+
+func decorator(f func(extractor.Extractor) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		if len(extractors) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 extractor, got %d", ErrInvalidNode, len(extractors))
+		}
+		return f(extractors[0]), nil
+	}
+}
+
+func decoratorWithStrings(f func(extractor.Extractor, ...string) extractor.Extractor) ExtractorConstructor {
+	return func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error) {
+		if len(extractors) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 extractor, got %d", ErrInvalidNode, len(extractors))
+		}
+		values, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return f(extractors[0], values...), nil
+	}
+}
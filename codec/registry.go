@@ -0,0 +1,666 @@
+package codec
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownPredicate is returned when a predicate is not registered under the name it is described by or a Node
+	// refers to a type that is not registered.
+	ErrUnknownPredicate = errors.New("unknown predicate")
+	// ErrUnknownExtractor is returned when an extractor is not registered under the name it is described by or the
+	// Node of an extractor refers to a type that is not registered.
+	ErrUnknownExtractor = errors.New("unknown extractor")
+	// ErrInvalidNode is returned when a Node's arguments or nested predicates do not fit its type.
+	ErrInvalidNode = errors.New("invalid predicate definition")
+	// ErrUnencodable is returned when a predicate is described with an extractor.Opaque argument, e.g. the keys a JWT
+	// is verified with, that can not be written to a Node.
+	ErrUnencodable = errors.New("predicate can not be encoded")
+)
+
+// Constructor builds a predicate from the arguments and nested predicates of a Node.
+type Constructor func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error)
+
+// ExtractorConstructor builds an extractor from the arguments and nested extractors of a Node.
+type ExtractorConstructor func(args Args, extractors []extractor.Extractor) (extractor.Extractor, error)
+
+// Registry maps the names predicates and extractors are described by to the constructors that build them.
+type Registry struct {
+	mu           sync.RWMutex
+	constructors map[string]Constructor
+	extractors   map[string]ExtractorConstructor
+}
+
+// DefaultRegistry is the registry used by the package level functions.  It contains the predicates of the predicate
+// package and the extractors of the extractor package.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a registry that contains the predicates of the predicate package and the extractors of the
+// extractor package.
+func NewRegistry() *Registry {
+	r := &Registry{constructors: make(map[string]Constructor), extractors: make(map[string]ExtractorConstructor)}
+	for name, constructor := range builtins {
+		r.Register(name, constructor)
+	}
+	for name, constructor := range builtinExtractors {
+		r.RegisterExtractor(name, constructor)
+	}
+	return r
+}
+
+// Register registers the constructor under the name in the DefaultRegistry.
+func Register(name string, constructor Constructor) {
+	DefaultRegistry.Register(name, constructor)
+}
+
+// Register registers the constructor under the name, replacing any constructor already registered under it.  To be
+// encodable, the predicates built by the constructor must describe themselves using the same name, e.g. by returning a
+// predicate.DescribedPredicate.
+func (r *Registry) Register(name string, constructor Constructor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.constructors[name] = constructor
+}
+
+func (r *Registry) lookup(name string) (Constructor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	constructor, ok := r.constructors[name]
+	return constructor, ok
+}
+
+// RegisterExtractor registers the extractor constructor under the name in the DefaultRegistry.
+func RegisterExtractor(name string, constructor ExtractorConstructor) {
+	DefaultRegistry.RegisterExtractor(name, constructor)
+}
+
+// RegisterExtractor registers the extractor constructor under the name, replacing any constructor already registered
+// under it.  Extractors are encoded as the first nested Node of an ExtractedValueAccepted predicate.  To be encodable,
+// the extractors built by the constructor must describe themselves using the same name, e.g. by returning an
+// extractor.DescribedExtractor.
+func (r *Registry) RegisterExtractor(name string, constructor ExtractorConstructor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extractors[name] = constructor
+}
+
+func (r *Registry) lookupExtractor(name string) (ExtractorConstructor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	constructor, ok := r.extractors[name]
+	return constructor, ok
+}
+
+// Args are the arguments of a Node.  The accessors convert the values produced by the JSON and YAML decoders to the
+// types the constructors expect.
+type Args []interface{}
+
+// String returns the i'th argument as a string.
+func (a Args) String(i int) (string, error) {
+	if i >= len(a) {
+		return "", fmt.Errorf("%w: missing argument %d", ErrInvalidNode, i+1)
+	}
+	s, ok := a[i].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: argument %d must be a string, got %v", ErrInvalidNode, i+1, a[i])
+	}
+	return s, nil
+}
+
+// Int returns the i'th argument as an int.
+func (a Args) Int(i int) (int, error) {
+	if i >= len(a) {
+		return 0, fmt.Errorf("%w: missing argument %d", ErrInvalidNode, i+1)
+	}
+	switch n := a[i].(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: argument %d must be an integer, got %v", ErrInvalidNode, i+1, a[i])
+}
+
+// Float returns the i'th argument as a float64.
+func (a Args) Float(i int) (float64, error) {
+	if i >= len(a) {
+		return 0, fmt.Errorf("%w: missing argument %d", ErrInvalidNode, i+1)
+	}
+	switch n := a[i].(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("%w: argument %d must be a number, got %v", ErrInvalidNode, i+1, a[i])
+}
+
+// Time returns the i'th argument, a date and time in RFC 3339 format, as a time.Time.
+func (a Args) Time(i int) (time.Time, error) {
+	if i < len(a) {
+		if t, ok := a[i].(time.Time); ok {
+			return t, nil
+		}
+	}
+	s, err := a.String(i)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: argument %d: %v", ErrInvalidNode, i+1, err)
+	}
+	return t, nil
+}
+
+// Bound returns the i'th argument as the bound of a comparison: a time.Time if it is a date and time in RFC 3339
+// format, an int if it is an integer and a float64 otherwise.
+func (a Args) Bound(i int) (interface{}, error) {
+	if i < len(a) {
+		switch a[i].(type) {
+		case string, time.Time:
+			return a.Time(i)
+		}
+	}
+	if n, err := a.Int(i); err == nil {
+		return n, nil
+	}
+	return a.Float(i)
+}
+
+// Regexp returns the i'th argument compiled as a regular expression.
+func (a Args) Regexp(i int) (*regexp.Regexp, error) {
+	s, err := a.String(i)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: argument %d: %v", ErrInvalidNode, i+1, err)
+	}
+	return re, nil
+}
+
+// Expect returns an error unless there are exactly n arguments.
+func (a Args) Expect(n int) error {
+	if len(a) != n {
+		return fmt.Errorf("%w: expected %d arguments, got %d", ErrInvalidNode, n, len(a))
+	}
+	return nil
+}
+
+var builtins = map[string]Constructor{
+	"And": func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		return predicate.And(predicates...), nil
+	},
+	"Or": func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		return predicate.Or(predicates...), nil
+	},
+	"Not": func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		if len(predicates) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 predicate, got %d", ErrInvalidNode, len(predicates))
+		}
+		return predicate.Not(predicates[0]), nil
+	},
+	"RequestMatches": func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		if len(predicates) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 predicate, got %d", ErrInvalidNode, len(predicates))
+		}
+		return predicate.RequestMatches(predicates[0]), nil
+	},
+	"True":  noArgs(predicate.True),
+	"False": noArgs(predicate.False),
+
+	"AnyValue":    onePredicate(predicate.AnyValue),
+	"AllValues":   onePredicate(predicate.AllValues),
+	"ValueCount":  oneInt(predicate.ValueCount),
+	"ContainsAll": manyStrings(predicate.ContainsAll),
+
+	"StringEquals":          oneString(predicate.StringEquals),
+	"StringContains":        oneString(predicate.StringContains),
+	"StringStartsWith":      oneString(predicate.StringStartsWith),
+	"StringEndsWith":        oneString(predicate.StringEndsWith),
+	"StringMatches":         oneRegexp(predicate.StringMatches),
+	"StringIn":              manyStrings(predicate.StringIn),
+	"StringMatchesTemplate": oneString(predicate.StringMatchesTemplate),
+	"IsPresent":             noArgs(predicate.IsPresent),
+	"IsAbsent":              noArgs(predicate.IsAbsent),
+	"JSONEquals":            oneValue(predicate.JSONEquals),
+	"JSONEqualsIgnoreCase":  oneString(predicate.JSONEqualsIgnoreCase),
+	"JSONMatches":           oneRegexp(predicate.JSONMatches),
+	"JSONContains":          oneValue(predicate.JSONContains),
+	"JSONNotEmpty":          noArgs(predicate.JSONNotEmpty),
+	"IPInCIDR":              manyStrings(predicate.IPInCIDR),
+	"IPIsPrivate":           noArgs(predicate.IPIsPrivate),
+	"IPIsLoopback":          noArgs(predicate.IPIsLoopback),
+	"MediaTypeIs":           oneString(predicate.MediaTypeIs),
+	"IncludesMediaType":     oneString(predicate.IncludesMediaType),
+	"IncludesLanguage":      oneString(predicate.IncludesLanguage),
+	"IncludesEncoding":      oneString(predicate.IncludesEncoding),
+	"TokenNotExpired": noArgs(func() predicate.Predicate {
+		return predicate.TokenNotExpired(nil)
+	}),
+
+	"MethodIs": oneString(predicate.MethodIs),
+
+	"PathEquals":     oneString(predicate.PathEquals),
+	"PathStartsWith": oneString(predicate.PathStartsWith),
+	"PathMatches":    oneRegexp(predicate.PathMatches),
+	"PathTemplate":   oneString(predicate.PathTemplate),
+
+	"PathParamEquals": threeStrings(predicate.PathParamEquals),
+
+	"SchemeEquals":       oneString(predicate.SchemeEquals),
+	"SchemeMatches":      oneRegexp(predicate.SchemeMatches),
+	"SchemeIn":           manyStrings(predicate.SchemeIn),
+	"HostnameEquals":     oneString(predicate.HostnameEquals),
+	"HostnameMatches":    oneRegexp(predicate.HostnameMatches),
+	"HostnameIn":         manyStrings(predicate.HostnameIn),
+	"HostIn":             manyStrings(predicate.HostIn),
+	"PortEquals":         oneString(predicate.PortEquals),
+	"PortMatches":        oneRegexp(predicate.PortMatches),
+	"PortIn":             manyStrings(predicate.PortIn),
+	"RawPathEquals":      oneString(predicate.RawPathEquals),
+	"RawPathMatches":     oneRegexp(predicate.RawPathMatches),
+	"RawPathIn":          manyStrings(predicate.RawPathIn),
+	"EscapedPathEquals":  oneString(predicate.EscapedPathEquals),
+	"EscapedPathMatches": oneRegexp(predicate.EscapedPathMatches),
+	"EscapedPathIn":      manyStrings(predicate.EscapedPathIn),
+
+	"RequestURIEquals":     oneString(predicate.RequestURIEquals),
+	"RequestURIStartsWith": oneString(predicate.RequestURIStartsWith),
+	"RequestURIMatches":    oneRegexp(predicate.RequestURIMatches),
+
+	"HeaderEquals":             twoStrings(predicate.HeaderEquals),
+	"HeaderEqualsIgnoreCase":   twoStrings(predicate.HeaderEqualsIgnoreCase),
+	"HeaderContains":           twoStrings(predicate.HeaderContains),
+	"HeaderContainsIgnoreCase": twoStrings(predicate.HeaderContainsIgnoreCase),
+	"HeaderStartsWith":         twoStrings(predicate.HeaderStartsWith),
+	"HeaderMatches":            stringAndRegexp(predicate.HeaderMatches),
+	"HeaderExists":             oneString(predicate.HeaderExists),
+	"HeaderAbsent":             oneString(predicate.HeaderAbsent),
+
+	"WithTrustedProxies": func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if len(predicates) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 predicate, got %d", ErrInvalidNode, len(predicates))
+		}
+		proxies := make([]string, 0, len(args))
+		for i := range args {
+			proxy, err := args.String(i)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, proxy)
+		}
+		return predicate.WithTrustedProxies(predicates[0], proxies...), nil
+	},
+	"ClientIPInCIDR":     manyStrings(predicate.ClientIPInCIDR),
+	"ClientIPIsPrivate":  noArgs(predicate.ClientIPIsPrivate),
+	"ClientIPIsLoopback": noArgs(predicate.ClientIPIsLoopback),
+	"ClientIPIn": manyStrings(func(cidrs ...string) predicate.Predicate {
+		return predicate.ClientIPIn(extractor.MustIPSet(cidrs...))
+	}),
+
+	"ContentTypeIs":    oneString(predicate.ContentTypeIs),
+	"AcceptsMediaType": oneString(predicate.AcceptsMediaType),
+	"AcceptsLanguage":  oneString(predicate.AcceptsLanguage),
+	"AcceptsEncoding":  oneString(predicate.AcceptsEncoding),
+
+	"BasicAuthUser":     oneString(predicate.BasicAuthUser),
+	"BasicAuth":         twoStrings(predicate.BasicAuth),
+	"BearerTokenEquals": oneString(predicate.BearerTokenEquals),
+	"JWTClaimEquals": stringAndValue(func(name string, value interface{}) predicate.Predicate {
+		return predicate.JWTClaimEquals(name, value)
+	}),
+	"JWTHasScope": oneString(func(scope string) predicate.Predicate {
+		return predicate.JWTHasScope(scope)
+	}),
+	"JWTNotExpired": noArgs(func() predicate.Predicate {
+		return predicate.JWTNotExpired(nil)
+	}),
+
+	"QueryParamGreaterThan":    stringAndFloat(predicate.QueryParamGreaterThan),
+	"QueryParamLessThan":       stringAndFloat(predicate.QueryParamLessThan),
+	"QueryParamBetween":        stringAndTwoFloats(predicate.QueryParamBetween),
+	"ContentLengthGreaterThan": oneInt64(predicate.ContentLengthGreaterThan),
+	"ContentLengthLessThan":    oneInt64(predicate.ContentLengthLessThan),
+	"ContentLengthBetween":     twoInt64s(predicate.ContentLengthBetween),
+	"GreaterThan":              oneBound(predicate.GreaterThan),
+	"LessThan":                 oneBound(predicate.LessThan),
+	"Between":                  twoBounds(predicate.Between),
+	"NumericEquals":            oneBound(predicate.NumericEquals),
+
+	"StatusIs":                   oneInt(predicate.StatusIs),
+	"StatusIn":                   manyStrings(predicate.StatusIn),
+	"ResponseHeaderEquals":       twoStrings(predicate.ResponseHeaderEquals),
+	"ResponseHeaderContains":     twoStrings(predicate.ResponseHeaderContains),
+	"ResponseHeaderMatches":      stringAndRegexp(predicate.ResponseHeaderMatches),
+	"ResponseTrailerEquals":      twoStrings(predicate.ResponseTrailerEquals),
+	"ResponseBodyXPathEquals":    twoStrings(predicate.ResponseBodyXPathEquals),
+	"ResponseBodyXPathMatches":   stringAndRegexp(predicate.ResponseBodyXPathMatches),
+	"ResponseBodyJSONPathEquals": stringAndValue(predicate.ResponseBodyJSONPathEquals),
+
+	"CookieEquals":     twoStrings(predicate.CookieEquals),
+	"CookieMatches":    stringAndRegexp(predicate.CookieMatches),
+	"CookieStartsWith": twoStrings(predicate.CookieStartsWith),
+	"CookieExists":     oneString(predicate.CookieExists),
+
+	"QueryParamEquals":             twoStrings(predicate.QueryParamEquals),
+	"QueryParamEqualsIgnoreCase":   twoStrings(predicate.QueryParamEqualsIgnoreCase),
+	"QueryParamContains":           twoStrings(predicate.QueryParamContains),
+	"QueryParamContainsIgnoreCase": twoStrings(predicate.QueryParamContainsIgnoreCase),
+	"QueryParamStartsWith":         twoStrings(predicate.QueryParamStartsWith),
+	"QueryParamMatches":            stringAndRegexp(predicate.QueryParamMatches),
+	"QueryParamExists":             oneString(predicate.QueryParamExists),
+	"QueryParamAbsent":             oneString(predicate.QueryParamAbsent),
+
+	"FormFieldEquals":             twoStrings(predicate.FormFieldEquals),
+	"FormFieldEqualsIgnoreCase":   twoStrings(predicate.FormFieldEqualsIgnoreCase),
+	"FormFieldContains":           twoStrings(predicate.FormFieldContains),
+	"FormFieldMatches":            stringAndRegexp(predicate.FormFieldMatches),
+	"FormFieldStartsWith":         twoStrings(predicate.FormFieldStartsWith),
+	"FormFieldExists":             oneString(predicate.FormFieldExists),
+	"MultipartFileNameEquals":     twoStrings(predicate.MultipartFileNameEquals),
+	"MultipartFileNameMatches":    stringAndRegexp(predicate.MultipartFileNameMatches),
+	"MultipartContentTypeEquals":  twoStrings(predicate.MultipartContentTypeEquals),
+	"MultipartContentTypeMatches": stringAndRegexp(predicate.MultipartContentTypeMatches),
+
+	"BodyXPathEquals":           twoStrings(predicate.BodyXPathEquals),
+	"BodyXPathEqualsIgnoreCase": twoStrings(predicate.BodyXPathEqualsIgnoreCase),
+	"BodyXPathMatches":          stringAndRegexp(predicate.BodyXPathMatches),
+	"BodyXPathExists":           oneString(predicate.BodyXPathExists),
+
+	"BodyJSONPathEquals":           stringAndValue(predicate.BodyJSONPathEquals),
+	"BodyJSONPathEqualsIgnoreCase": twoStrings(predicate.BodyJSONPathEqualsIgnoreCase),
+	"BodyJSONPathMatches":          stringAndRegexp(predicate.BodyJSONPathMatches),
+	"BodyJSONPathContains":         stringAndValue(predicate.BodyJSONPathContains),
+	"BodyJSONPathExists":           oneString(predicate.BodyJSONPathExists),
+	"BodyJSONPointerEquals":        stringAndValue(predicate.BodyJSONPointerEquals),
+}
+
+func leaf(args Args, predicates []predicate.Predicate, n int) error {
+	if len(predicates) != 0 {
+		return fmt.Errorf("%w: does not take nested predicates", ErrInvalidNode)
+	}
+	return args.Expect(n)
+}
+
+func onePredicate(f func(predicate.Predicate) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(0); err != nil {
+			return nil, err
+		}
+		if len(predicates) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 predicate, got %d", ErrInvalidNode, len(predicates))
+		}
+		return f(predicates[0]), nil
+	}
+}
+
+func noArgs(f func() predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 0); err != nil {
+			return nil, err
+		}
+		return f(), nil
+	}
+}
+
+func oneString(f func(string) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 1); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(s), nil
+	}
+}
+
+func manyStrings(f func(...string) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, len(args)); err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(args))
+		for i := range args {
+			s, err := args.String(i)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s)
+		}
+		return f(values...), nil
+	}
+}
+
+func oneRegexp(f func(*regexp.Regexp) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 1); err != nil {
+			return nil, err
+		}
+		re, err := args.Regexp(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(re), nil
+	}
+}
+
+func twoStrings(f func(string, string) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		s1, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		s2, err := args.String(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(s1, s2), nil
+	}
+}
+
+func threeStrings(f func(string, string, string) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 3); err != nil {
+			return nil, err
+		}
+		s1, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		s2, err := args.String(1)
+		if err != nil {
+			return nil, err
+		}
+		s3, err := args.String(2)
+		if err != nil {
+			return nil, err
+		}
+		return f(s1, s2, s3), nil
+	}
+}
+
+func stringAndRegexp(f func(string, *regexp.Regexp) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		re, err := args.Regexp(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(s, re), nil
+	}
+}
+
+func oneValue(f func(interface{}) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 1); err != nil {
+			return nil, err
+		}
+		return f(args[0]), nil
+	}
+}
+
+func stringAndValue(f func(string, interface{}) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(s, args[1]), nil
+	}
+}
+
+func oneInt(f func(int) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 1); err != nil {
+			return nil, err
+		}
+		n, err := args.Int(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(n), nil
+	}
+}
+
+func oneInt64(f func(int64) predicate.Predicate) Constructor {
+	return oneInt(func(n int) predicate.Predicate {
+		return f(int64(n))
+	})
+}
+
+func twoInt64s(f func(int64, int64) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		n1, err := args.Int(0)
+		if err != nil {
+			return nil, err
+		}
+		n2, err := args.Int(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(int64(n1), int64(n2)), nil
+	}
+}
+
+func stringAndFloat(f func(string, float64) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		f1, err := args.Float(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(s, f1), nil
+	}
+}
+
+func stringAndTwoFloats(f func(string, float64, float64) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 3); err != nil {
+			return nil, err
+		}
+		s, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		f1, err := args.Float(1)
+		if err != nil {
+			return nil, err
+		}
+		f2, err := args.Float(2)
+		if err != nil {
+			return nil, err
+		}
+		return f(s, f1, f2), nil
+	}
+}
+
+func oneBound(f func(interface{}) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 1); err != nil {
+			return nil, err
+		}
+		bound, err := args.Bound(0)
+		if err != nil {
+			return nil, err
+		}
+		return f(bound), nil
+	}
+}
+
+func twoBounds(f func(interface{}, interface{}) predicate.Predicate) Constructor {
+	return func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := leaf(args, predicates, 2); err != nil {
+			return nil, err
+		}
+		min, err := args.Bound(0)
+		if err != nil {
+			return nil, err
+		}
+		max, err := args.Bound(1)
+		if err != nil {
+			return nil, err
+		}
+		return f(min, max), nil
+	}
+}
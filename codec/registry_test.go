@@ -0,0 +1,90 @@
+package codec_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/codec"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func tenantIs(tenant string) predicate.Predicate {
+	return predicate.DescribedPredicate{
+		Name:      "TenantIs",
+		Args:      []interface{}{tenant},
+		Predicate: predicate.HeaderEquals("X-Tenant", tenant),
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	_, err := registry.EncodeJSON(tenantIs("a"))
+	assert.True(t, errors.Is(err, ErrUnknownPredicate))
+
+	registry.Register("TenantIs", func(args Args, predicates []predicate.Predicate) (predicate.Predicate, error) {
+		if err := args.Expect(1); err != nil {
+			return nil, err
+		}
+		tenant, err := args.String(0)
+		if err != nil {
+			return nil, err
+		}
+		return tenantIs(tenant), nil
+	})
+
+	data, err := registry.EncodeJSON(predicate.And(predicate.MethodIs("GET"), tenantIs("a")))
+	if assert.NoError(t, err) {
+		p, err := registry.DecodeJSON(data)
+		if assert.NoError(t, err) {
+			req, _ := http.NewRequest("GET", "http://foo.com/", nil)
+			req.Header.Add("X-Tenant", "a")
+			assert.True(t, p.Accept(req))
+			req.Header.Set("X-Tenant", "b")
+			assert.False(t, p.Accept(req))
+		}
+	}
+
+	_, err = DecodeJSON([]byte(`{"type": "TenantIs", "args": ["a"]}`))
+	assert.True(t, errors.Is(err, ErrUnknownPredicate), "registering with a registry should not affect the default one")
+}
+
+func TestArgs(t *testing.T) {
+	args := Args{"a", 1, float64(2), 2.5, "["}
+
+	s, err := args.String(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", s)
+	_, err = args.String(1)
+	assert.True(t, errors.Is(err, ErrInvalidNode))
+	_, err = args.String(5)
+	assert.True(t, errors.Is(err, ErrInvalidNode))
+
+	n, err := args.Int(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = args.Int(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, err = args.Int(3)
+	assert.True(t, errors.Is(err, ErrInvalidNode))
+
+	bound, err := args.Bound(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bound)
+	bound, err = args.Bound(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, bound)
+	_, err = args.Bound(0)
+	assert.True(t, errors.Is(err, ErrInvalidNode))
+	tm, err := Args{"2024-01-01T12:00:00+02:00"}.Time(0)
+	assert.NoError(t, err)
+	assert.True(t, tm.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)))
+
+	re, err := args.Regexp(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", re.String())
+	_, err = args.Regexp(4)
+	assert.True(t, errors.Is(err, ErrInvalidNode))
+}
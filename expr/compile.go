@@ -0,0 +1,248 @@
+package expr
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"gopkg.in/xmlpath.v2"
+	"regexp"
+	"strconv"
+)
+
+// Compile parses the expression and returns the predicate it describes.  Syntax errors, invalid regular expressions
+// and invalid XPath expressions are returned as a *SyntaxError.
+func Compile(src string) (predicate.Predicate, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok, "'&&', '||' or end of expression")
+	}
+	return result, nil
+}
+
+// MustCompile is like Compile but panics if the expression can not be compiled.
+func MustCompile(src string) predicate.Predicate {
+	p, err := Compile(src)
+	if err != nil {
+		panic("expr: Compile(" + strconv.Quote(src) + "): " + err.Error())
+	}
+	return p
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	tok := p.advance()
+	if tok.kind != kind {
+		return tok, p.unexpected(tok, kind.String())
+	}
+	return tok, nil
+}
+
+func (p *parser) unexpected(tok token, expected string) error {
+	return newSyntaxError(p.src, tok.pos, "expected %s, found %s", expected, tok)
+}
+
+// parseOr parses: and { "||" and }
+func (p *parser) parseOr() (predicate.Predicate, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	predicates := []predicate.Predicate{first}
+	for p.peek().kind == tokenOr {
+		p.advance()
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, next)
+	}
+	if len(predicates) == 1 {
+		return first, nil
+	}
+	return predicate.Or(predicates...), nil
+}
+
+// parseAnd parses: unary { "&&" unary }
+func (p *parser) parseAnd() (predicate.Predicate, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	predicates := []predicate.Predicate{first}
+	for p.peek().kind == tokenAnd {
+		p.advance()
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, next)
+	}
+	if len(predicates) == 1 {
+		return first, nil
+	}
+	return predicate.And(predicates...), nil
+}
+
+// parseUnary parses: "!" unary | "(" or ")" | "true" | "false" | comparison
+func (p *parser) parseUnary() (predicate.Predicate, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenNot:
+		p.advance()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Not(operand), nil
+	case tok.kind == tokenLParen:
+		p.advance()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tok.kind == tokenIdent && tok.text == "true":
+		p.advance()
+		return predicate.True(), nil
+	case tok.kind == tokenIdent && tok.text == "false":
+		p.advance()
+		return predicate.False(), nil
+	case tok.kind == tokenIdent:
+		return p.parseComparison()
+	}
+	return nil, p.unexpected(tok, "field, '!' or '('")
+}
+
+// parseComparison parses: field operator string
+func (p *parser) parseComparison() (predicate.Predicate, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+	op := p.advance()
+	switch {
+	case op.kind == tokenEquals, op.kind == tokenNotEquals, op.kind == tokenMatches, op.kind == tokenNotMatches:
+	case op.kind == tokenIdent && (op.text == "contains" || op.text == "startsWith" || op.text == "endsWith"):
+	default:
+		return nil, p.unexpected(op, "comparison operator")
+	}
+	value, err := p.expect(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	var result predicate.Predicate
+	switch op.kind {
+	case tokenEquals, tokenNotEquals:
+		result = predicate.StringEquals(value.text)
+	case tokenMatches, tokenNotMatches:
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, newSyntaxError(p.src, value.pos, "invalid regular expression: %v", err)
+		}
+		result = predicate.StringMatches(re)
+	default:
+		switch op.text {
+		case "contains":
+			result = predicate.StringContains(value.text)
+		case "startsWith":
+			result = predicate.StringStartsWith(value.text)
+		case "endsWith":
+			result = predicate.StringEndsWith(value.text)
+		}
+	}
+	result = predicate.ExtractedValueAccepted(field, result)
+	if op.kind == tokenNotEquals || op.kind == tokenNotMatches {
+		result = predicate.Not(result)
+	}
+	return result, nil
+}
+
+// parseField parses: name [ "[" (string | integer) "]" ]
+func (p *parser) parseField() (extractor.Extractor, error) {
+	name := p.advance()
+	switch name.text {
+	case "method":
+		return extractor.ExtractMethod(), nil
+	case "uri":
+		return extractor.ExtractRequestURI(), nil
+	case "host":
+		return extractor.ExtractHost(), nil
+	case "path":
+		if p.peek().kind != tokenLBracket {
+			return extractor.ExtractPath(), nil
+		}
+		p.advance()
+		idx, err := p.expect(tokenInt)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(idx.text)
+		if err != nil {
+			return nil, newSyntaxError(p.src, idx.pos, "invalid path index %s", idx.text)
+		}
+		if _, err := p.expect(tokenRBracket); err != nil {
+			return nil, err
+		}
+		return extractor.ExtractPathElementByIndex(n), nil
+	case "header", "query", "xpath":
+		if _, err := p.expect(tokenLBracket); err != nil {
+			return nil, err
+		}
+		key, err := p.expect(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRBracket); err != nil {
+			return nil, err
+		}
+		switch name.text {
+		case "header":
+			return extractor.ExtractHeader(key.text), nil
+		case "query":
+			return extractor.ExtractQueryParameter(key.text), nil
+		}
+		if _, err := xmlpath.Compile(key.text); err != nil {
+			return nil, newSyntaxError(p.src, key.pos, "invalid xpath expression: %v", err)
+		}
+		return extractor.ExtractXPathString(key.text), nil
+	}
+	return nil, newSyntaxError(p.src, name.pos, "unknown field %q", name.text)
+}
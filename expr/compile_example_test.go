@@ -0,0 +1,25 @@
+package expr_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/expr"
+	"net/http"
+)
+
+func ExampleCompile() {
+	p, err := expr.Compile(`method == "GET" && path =~ "^/api/" && header["X-Tenant"] == "a"`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	req, _ := http.NewRequest("GET", "http://foo.com/api/orders", nil)
+	req.Header.Add("X-Tenant", "a")
+	fmt.Println(p.Accept(req))
+	// Output: true
+}
+
+func ExampleSyntaxError() {
+	_, err := expr.Compile(`method == "GET" && path =~ ^/api/`)
+	fmt.Println(err)
+	// Output: 1:28: unexpected character '^'
+}
@@ -0,0 +1,116 @@
+package expr_test
+
+import (
+	"fmt"
+	. "github.com/danapsimer/go-http-matchers/expr"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newRequest(t *testing.T) *http.Request {
+	req, err := http.NewRequest("POST", "http://foo.com/api/orders/42?tenant=a&l=3",
+		strings.NewReader(`<order><id>42</id></order>`))
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("X-Tenant", "a")
+	return req
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		Expr     string
+		Expected bool
+	}{
+		{`method == "POST"`, true},
+		{`method != "POST"`, false},
+		{`path == "/api/orders/42"`, true},
+		{`path =~ "^/api/"`, true},
+		{`path !~ "^/api/"`, false},
+		{"path =~ `^/api/orders/\\d+$`", true},
+		{`path[2] == "orders"`, true},
+		{`path[-1] == "42"`, true},
+		{`uri == "/api/orders/42?tenant=a&l=3"`, true},
+		{`host == "foo.com"`, true},
+		{`header["X-Tenant"] == "a"`, true},
+		{`header["X-Tenant"] == "b"`, false},
+		{`query["tenant"] startsWith "a"`, true},
+		{`query["l"] endsWith "3"`, true},
+		{`uri contains "tenant="`, true},
+		{`xpath["/order/id"] == "42"`, true},
+		{`true`, true},
+		{`false`, false},
+		{`method == "GET" || path =~ "^/api/"`, true},
+		{`method == "GET" && path =~ "^/api/"`, false},
+		{`!(method == "GET") && path =~ "^/api/"`, true},
+		{`method == "GET" && false || true`, true},
+		{`method == "GET" && (false || true)`, false},
+		{`!!true`, true},
+		{"method == \"POST\"\n  && header[\"X-Tenant\"] == \"a\"", true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Expr, func(t *testing.T) {
+			p, err := Compile(tst.Expr)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tst.Expected, p.Accept(newRequest(t)))
+			}
+		})
+	}
+}
+
+func TestCompile_Describe(t *testing.T) {
+	p := MustCompile(`method == "GET" && !(header["X-Tenant"] =~ "^a")`)
+	assert.Equal(t, `And(ExtractedValueAccepted(ExtractMethod(), StringEquals("GET")), `+
+		`Not(ExtractedValueAccepted(ExtractHeader("X-Tenant"), StringMatches(/^a/))))`, fmt.Sprint(p))
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		Expr  string
+		Error string
+	}{
+		{``, `1:1: expected field, '!' or '(', found end of expression`},
+		{`method`, `1:7: expected comparison operator, found end of expression`},
+		{`method = "GET"`, `1:8: unexpected character '='`},
+		{`method == GET`, `1:11: expected string, found identifier "GET"`},
+		{`method == "GET`, `1:11: unterminated string`},
+		{`method == "GET" &&`, `1:19: expected field, '!' or '(', found end of expression`},
+		{`method == "GET" "POST"`, `1:17: expected '&&', '||' or end of expression, found string "POST"`},
+		{`(method == "GET"`, `1:17: expected ')', found end of expression`},
+		{`verb == "GET"`, `1:1: unknown field "verb"`},
+		{`header == "a"`, `1:8: expected '[', found '=='`},
+		{`header[1] == "a"`, `1:8: expected string, found integer "1"`},
+		{`header["a" == "a"`, `1:12: expected ']', found '=='`},
+		{`path["a"] == "a"`, `1:6: expected integer, found string "a"`},
+		{"method == \"GET\" &&\n  path =~ \"(\"", `2:11: invalid regular expression: error parsing regexp: missing closing ): ` + "`(`"},
+		{`xpath["/a["] == "a"`, `1:7: invalid xpath expression`},
+		{`method == "GET" # comment`, `1:17: unexpected character '#'`},
+		{`path[-] == "a"`, `1:6: unexpected character '-'`},
+		{`path[٣] == "a"`, `1:6: unexpected character '٣'`},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Expr, func(t *testing.T) {
+			_, err := Compile(tst.Expr)
+			if assert.Error(t, err) {
+				assert.IsType(t, &SyntaxError{}, err)
+				assert.True(t, strings.HasPrefix(err.Error(), tst.Error), err.Error())
+			}
+		})
+	}
+}
+
+func TestSyntaxError_Position(t *testing.T) {
+	_, err := Compile("method == \"GET\"\n&& höst == \"a\"")
+	if assert.IsType(t, &SyntaxError{}, err) {
+		syntaxErr := err.(*SyntaxError)
+		assert.Equal(t, 19, syntaxErr.Offset)
+		assert.Equal(t, 2, syntaxErr.Line)
+		assert.Equal(t, 4, syntaxErr.Column)
+	}
+}
+
+func TestMustCompile_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		MustCompile(`method ==`)
+	})
+}
@@ -0,0 +1,61 @@
+// Package expr compiles boolean expressions into predicates so that request matching rules can be written in
+// configuration files and command line flags, e.g.
+//
+//	method == "GET" && path =~ "^/api/" && header["X-Tenant"] == "a"
+//
+// An expression compares a field of the request with a string using one of the operators below.  Comparisons can be
+// combined with && and ||, negated with ! and grouped with parentheses.  && binds more tightly than ||.
+//
+//	Fields                  Operators
+//	method                  ==          equals
+//	path                    !=          does not equal
+//	path[n]                 =~          matches the regular expression
+//	uri                     !~          does not match the regular expression
+//	host                    contains    contains the string
+//	header["name"]          startsWith  starts with the string
+//	query["name"]           endsWith    ends with the string
+//	xpath["expression"]
+//
+// The fields map onto the extractors ExtractMethod, ExtractPath, ExtractPathElementByIndex, ExtractRequestURI,
+// ExtractHost, ExtractHeader, ExtractQueryParameter and ExtractXPathString, and the operators map onto StringEquals,
+// StringMatches, StringContains, StringStartsWith and StringEndsWith.  Strings are written using Go syntax, either in
+// double quotes or, to avoid escaping regular expressions, in back quotes.  The literals true and false match every
+// request and no request respectively.
+package expr
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError is returned by Compile when an expression can not be parsed.  Offset is the byte offset of the error in
+// the expression while Line and Column give the same position starting at 1.
+type SyntaxError struct {
+	Offset int
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error returns the position and the message, e.g. "1:12: expected string, found identifier \"foo\"".
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+func newSyntaxError(src string, offset int, format string, args ...interface{}) *SyntaxError {
+	before := src[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := len([]rune(before[strings.LastIndex(before, "\n")+1:])) + 1
+	return &SyntaxError{Offset: offset, Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,183 @@
+package expr
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEquals
+	tokenNotEquals
+	tokenMatches
+	tokenNotMatches
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:        "end of expression",
+	tokenIdent:      "identifier",
+	tokenString:     "string",
+	tokenInt:        "integer",
+	tokenLParen:     "'('",
+	tokenRParen:     "')'",
+	tokenLBracket:   "'['",
+	tokenRBracket:   "']'",
+	tokenAnd:        "'&&'",
+	tokenOr:         "'||'",
+	tokenNot:        "'!'",
+	tokenEquals:     "'=='",
+	tokenNotEquals:  "'!='",
+	tokenMatches:    "'=~'",
+	tokenNotMatches: "'!~'",
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+type token struct {
+	kind tokenKind
+	// text is the source text of the token, or the unquoted value of a string.
+	text string
+	// pos and end are the offsets of the first byte of the token and the byte following it.
+	pos, end int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenIdent, tokenInt:
+		return fmt.Sprintf("%s %q", t.kind, t.text)
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+	return t.kind.String()
+}
+
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"&&", tokenAnd},
+	{"||", tokenOr},
+	{"==", tokenEquals},
+	{"!=", tokenNotEquals},
+	{"=~", tokenMatches},
+	{"!~", tokenNotMatches},
+	{"!", tokenNot},
+	{"(", tokenLParen},
+	{")", tokenRParen},
+	{"[", tokenLBracket},
+	{"]", tokenRBracket},
+}
+
+// lex splits the source into tokens.  The last token is always tokenEOF.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for {
+		for pos < len(src) {
+			r, size := utf8.DecodeRuneInString(src[pos:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			pos += size
+		}
+		if pos >= len(src) {
+			return append(tokens, token{kind: tokenEOF, pos: pos, end: pos}), nil
+		}
+		tok, err := next(src, pos)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		pos = tok.end
+	}
+}
+
+func next(src string, pos int) (token, error) {
+	rest := src[pos:]
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op.text) {
+			return token{kind: op.kind, text: op.text, pos: pos, end: pos + len(op.text)}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	switch {
+	case r == '"' || r == '`':
+		n, err := scanString(src, pos)
+		if err != nil {
+			return token{}, err
+		}
+		value, err := strconv.Unquote(src[pos : pos+n])
+		if err != nil {
+			return token{}, newSyntaxError(src, pos, "invalid string literal %s", src[pos:pos+n])
+		}
+		return token{kind: tokenString, text: value, pos: pos, end: pos + n}, nil
+	case r == '-' || r >= '0' && r <= '9':
+		end := pos + 1
+		for end < len(src) && src[end] >= '0' && src[end] <= '9' {
+			end++
+		}
+		if r == '-' && end == pos+1 {
+			return token{}, newSyntaxError(src, pos, "unexpected character '-'")
+		}
+		return token{kind: tokenInt, text: src[pos:end], pos: pos, end: end}, nil
+	case unicode.IsLetter(r) || r == '_':
+		end := pos
+		for end < len(src) {
+			r, size := utf8.DecodeRuneInString(src[end:])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+				break
+			}
+			end += size
+		}
+		return token{kind: tokenIdent, text: src[pos:end], pos: pos, end: end}, nil
+	}
+	return token{}, newSyntaxError(src, pos, "unexpected character %q", r)
+}
+
+// scanString returns the length of the quoted string starting at pos, including the quotes.
+func scanString(src string, pos int) (int, error) {
+	quote := src[pos]
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1 - pos, nil
+		case '\n':
+			if quote == '"' {
+				return 0, newSyntaxError(src, pos, "unterminated string")
+			}
+		}
+	}
+	return 0, newSyntaxError(src, pos, "unterminated string")
+}
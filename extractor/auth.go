@@ -0,0 +1,89 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var authorizationHeader = ExtractHeader("Authorization")
+
+// credentials returns the credentials of the Authorization header of the request if they use the scheme, which is
+// compared ignoring case.
+func credentials(r *http.Request, scheme string) (string, error) {
+	header, err := ExtractE(authorizationHeader, r)
+	if err != nil {
+		return "", err
+	}
+	if header == "" {
+		return "", fmt.Errorf("%w: no Authorization header", ErrMissing)
+	}
+	s, creds, _ := strings.Cut(strings.TrimSpace(header.(string)), " ")
+	if !strings.EqualFold(s, scheme) {
+		return "", fmt.Errorf("%w: Authorization header does not hold %s credentials", ErrMissing, scheme)
+	}
+	return strings.TrimSpace(creds), nil
+}
+
+// basicAuth returns the username and password of the Basic credentials of the request.
+func basicAuth(r *http.Request) (string, string, error) {
+	creds, err := credentials(r, "Basic")
+	if err != nil {
+		return "", "", err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(creds)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: Basic credentials: %v", ErrParse, err)
+	}
+	user, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", fmt.Errorf("%w: Basic credentials have no ':'", ErrParse)
+	}
+	return user, password, nil
+}
+
+// ExtractBasicAuthUser returns an Extractor that expects a *http.Request and returns the username of the Basic
+// credentials of its Authorization header.  It returns "" and an error wrapping ErrMissing if the request has no Basic
+// credentials and one wrapping ErrParse if they can not be decoded.
+func ExtractBasicAuthUser() Extractor {
+	description := describe("ExtractBasicAuthUser")
+	return requestExtractor("basic auth user", description, "", func(r *http.Request) (interface{}, error) {
+		user, _, err := basicAuth(r)
+		return user, err
+	})
+}
+
+// ExtractBasicAuthPassword returns an Extractor that expects a *http.Request and returns the password of the Basic
+// credentials of its Authorization header.  See ExtractBasicAuthUser.
+func ExtractBasicAuthPassword() Extractor {
+	description := describe("ExtractBasicAuthPassword")
+	return requestExtractor("basic auth password", description, "", func(r *http.Request) (interface{}, error) {
+		_, password, err := basicAuth(r)
+		return password, err
+	})
+}
+
+// ExtractBearerToken returns an Extractor that expects a *http.Request and returns the token of the Bearer credentials
+// of its Authorization header, or "" and an error wrapping ErrMissing if the request has no Bearer credentials.
+func ExtractBearerToken() Extractor {
+	description := describe("ExtractBearerToken")
+	return requestExtractor("bearer token", description, "", func(r *http.Request) (interface{}, error) {
+		token, err := credentials(r, "Bearer")
+		if err == nil && token == "" {
+			err = fmt.Errorf("%w: Bearer credentials are empty", ErrMissing)
+		}
+		return token, err
+	})
+}
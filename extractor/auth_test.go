@@ -0,0 +1,48 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func authRequest(t *testing.T, authorization string) *http.Request {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req
+}
+
+func TestExtractBasicAuth(t *testing.T) {
+	req := authRequest(t, "")
+	req.SetBasicAuth("admin", "s3cr:t")
+	assert.Equal(t, "admin", ExtractBasicAuthUser().Extract(req))
+	assert.Equal(t, "s3cr:t", ExtractBasicAuthPassword().Extract(req))
+	assert.Equal(t, "admin", ExtractBasicAuthUser().Extract(authRequest(t, "basic  YWRtaW46")))
+
+	_, err := ExtractE(ExtractBasicAuthUser(), authRequest(t, ""))
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractBasicAuthUser(), authRequest(t, "Bearer abc"))
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractBasicAuthUser(), authRequest(t, "Basic !!!"))
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	_, err = ExtractE(ExtractBasicAuthPassword(), authRequest(t, "Basic YWRtaW4="))
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestExtractBearerToken(t *testing.T) {
+	assert.Equal(t, "abc.def", ExtractBearerToken().Extract(authRequest(t, "Bearer abc.def")))
+	assert.Equal(t, "abc.def", ExtractBearerToken().Extract(authRequest(t, "bearer   abc.def ")))
+
+	value, err := ExtractE(ExtractBearerToken(), authRequest(t, "Basic YWRtaW46"))
+	assert.Equal(t, "", value)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractBearerToken(), authRequest(t, "Bearer"))
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractBearerToken(), &http.Response{})
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+}
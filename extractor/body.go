@@ -0,0 +1,203 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"gopkg.in/xmlpath.v2"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultMaxBodySize is the maximum number of bytes RequestBody reads into memory.  Use BufferBody to buffer a request
+// body with a different limit before it is passed to any extractor.
+var DefaultMaxBodySize int64 = 10 << 20
+
+// ErrBodyTooLarge is returned by BufferBody and BufferResponseBody when the body is larger than the maximum size.
+var ErrBodyTooLarge = errors.New("body too large")
+
+// Body is a request or response body that has been read into memory so that it can be inspected by any number of
+// extractors and still be read by the handler that eventually serves the request or the client that receives the
+// response.  It replaces the Body it was read from and reads exactly like the original body.  The parsed XML and JSON
+// representations of the body are computed at most once and shared by all of the extractors evaluated against it.
+type Body struct {
+	reader io.Reader
+	closer io.Closer
+	data   []byte
+	err    error
+
+	xmlOnce sync.Once
+	xmlRoot *xmlpath.Node
+	xmlErr  error
+
+	jsonOnce  sync.Once
+	jsonValue interface{}
+	jsonErr   error
+
+	formOnce sync.Once
+	form     *Form
+	formErr  error
+}
+
+// RequestBody buffers the body of the request using DefaultMaxBodySize.  See BufferBody.
+func RequestBody(r *http.Request) (*Body, error) {
+	return BufferBody(r, DefaultMaxBodySize)
+}
+
+// BufferBody reads the body of the request into memory and replaces r.Body and r.GetBody so that the body can be read
+// again.  If the body has already been buffered, the existing Body is returned.  If the body is larger than maxSize
+// bytes, ErrBodyTooLarge is returned and the Body holds no data, but the request body still reads in full.  A nil or
+// http.NoBody body is left as is and an empty Body is returned.
+func BufferBody(r *http.Request, maxSize int64) (*Body, error) {
+	if body, ok := r.Body.(*Body); ok {
+		return body, body.err
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return emptyBody(), nil
+	}
+	body, buffered := newBody(r.Body, maxSize)
+	if buffered {
+		data := body.data
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+	r.Body = body
+	return body, body.err
+}
+
+// ResponseBody buffers the body of the response using DefaultMaxBodySize.  See BufferResponseBody.
+func ResponseBody(resp *http.Response) (*Body, error) {
+	return BufferResponseBody(resp, DefaultMaxBodySize)
+}
+
+// BufferResponseBody reads the body of the response into memory and replaces resp.Body so that the body can be read
+// again.  Reading the body in full also fills in the response's Trailer.  See BufferBody.
+func BufferResponseBody(resp *http.Response, maxSize int64) (*Body, error) {
+	if body, ok := resp.Body.(*Body); ok {
+		return body, body.err
+	}
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return emptyBody(), nil
+	}
+	body, _ := newBody(resp.Body, maxSize)
+	resp.Body = body
+	return body, body.err
+}
+
+// emptyBody returns a Body holding no data.
+func emptyBody() *Body {
+	return &Body{reader: bytes.NewReader(nil)}
+}
+
+// newBody reads the original body into a Body and returns true if it was read in full.
+func newBody(original io.ReadCloser, maxSize int64) (*Body, bool) {
+	body := &Body{}
+	data, err := io.ReadAll(io.LimitReader(original, maxSize+1))
+	switch {
+	case err != nil:
+		body.err = err
+		body.reader = io.MultiReader(bytes.NewReader(data), errorReader{err})
+		body.closer = original
+	case int64(len(data)) > maxSize:
+		body.err = ErrBodyTooLarge
+		body.reader = io.MultiReader(bytes.NewReader(data), original)
+		body.closer = original
+	default:
+		body.data = data
+		body.reader = bytes.NewReader(data)
+		original.Close()
+		return body, true
+	}
+	return body, false
+}
+
+// Read reads from the body as if it had never been buffered.
+func (b *Body) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close closes the original body if it was not read in full.
+func (b *Body) Close() error {
+	if b.closer != nil {
+		return b.closer.Close()
+	}
+	return nil
+}
+
+// Bytes returns the content of the body.
+func (b *Body) Bytes() ([]byte, error) {
+	return b.data, b.err
+}
+
+// XML returns the body parsed as XML.
+func (b *Body) XML() (*xmlpath.Node, error) {
+	b.xmlOnce.Do(func() {
+		if b.err != nil {
+			b.xmlErr = b.err
+			return
+		}
+		b.xmlRoot, b.xmlErr = xmlpath.Parse(bytes.NewReader(b.data))
+	})
+	return b.xmlRoot, b.xmlErr
+}
+
+// JSON returns the body decoded as JSON.  Values are typed the way encoding/json decodes them into an interface{}.
+func (b *Body) JSON() (interface{}, error) {
+	b.jsonOnce.Do(func() {
+		if b.err != nil {
+			b.jsonErr = b.err
+			return
+		}
+		b.jsonErr = json.Unmarshal(b.data, &b.jsonValue)
+	})
+	return b.jsonValue, b.jsonErr
+}
+
+// Form returns the body parsed as an HTML form.  The content type, usually the request's Content-Type header, must be
+// either application/x-www-form-urlencoded or multipart/form-data.  The form is parsed once, using the content type
+// passed to the first call.
+func (b *Body) Form(contentType string) (*Form, error) {
+	b.formOnce.Do(func() {
+		if b.err != nil {
+			b.formErr = b.err
+			return
+		}
+		b.form, b.formErr = parseForm(contentType, b.data)
+	})
+	return b.form, b.formErr
+}
+
+type errorReader struct {
+	err error
+}
+
+func (er errorReader) Read([]byte) (int, error) {
+	return 0, er.err
+}
+
+// ExtractBody returns an Extractor that expects a *http.Request and returns its body as a string.  The body is
+// buffered so that it can still be read afterwards.  If the body can not be buffered, "" and the error returned by
+// RequestBody are returned.
+func ExtractBody() Extractor {
+	return requestExtractor("body", describe("ExtractBody"), "", func(r *http.Request) (interface{}, error) {
+		body, err := RequestBody(r)
+		if err != nil {
+			return "", err
+		}
+		data, _ := body.Bytes()
+		return string(data), nil
+	})
+}
@@ -0,0 +1,135 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type countingReader struct {
+	io.Reader
+	reads  int
+	closed bool
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	cr.reads++
+	return cr.Reader.Read(p)
+}
+
+func (cr *countingReader) Close() error {
+	cr.closed = true
+	return nil
+}
+
+func TestBufferBody(t *testing.T) {
+	original := &countingReader{Reader: strings.NewReader(`<foo><bar snafu="fubar"/></foo>`)}
+	req, err := http.NewRequest("POST", "http://foo.com/test", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Body = original
+
+	body, err := BufferBody(req, 1024)
+	assert.NoError(t, err)
+	data, err := body.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `<foo><bar snafu="fubar"/></foo>`, string(data))
+	assert.True(t, original.closed)
+	reads := original.reads
+
+	again, err := RequestBody(req)
+	assert.NoError(t, err)
+	assert.True(t, body == again, "expected the buffered body to be reused")
+	assert.Equal(t, reads, original.reads, "expected the original body not to be read again")
+
+	content, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `<foo><bar snafu="fubar"/></foo>`, string(content))
+
+	if assert.NotNil(t, req.GetBody) {
+		rc, err := req.GetBody()
+		assert.NoError(t, err)
+		content, err = io.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.Equal(t, `<foo><bar snafu="fubar"/></foo>`, string(content))
+	}
+}
+
+func TestBufferBody_TooLarge(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/test", strings.NewReader("0123456789"))
+	assert.NoError(t, err, "failed to create test request.")
+
+	body, err := BufferBody(req, 5)
+	assert.True(t, errors.Is(err, ErrBodyTooLarge))
+	data, err := body.Bytes()
+	assert.True(t, errors.Is(err, ErrBodyTooLarge))
+	assert.Empty(t, data)
+	_, err = body.JSON()
+	assert.True(t, errors.Is(err, ErrBodyTooLarge))
+
+	content, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(content), "expected the handler to still see the whole body")
+}
+
+func TestBufferBody_NoBody(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	body, err := RequestBody(req)
+	assert.NoError(t, err)
+	data, err := body.Bytes()
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+	_, err = body.JSON()
+	assert.Error(t, err)
+	assert.Nil(t, req.Body, "a nil body is left as is")
+
+	req.Body = http.NoBody
+	_, err = RequestBody(req)
+	assert.NoError(t, err)
+	assert.True(t, req.Body == http.NoBody, "http.NoBody is left as is")
+	assert.Nil(t, req.GetBody)
+
+	resp := &http.Response{Body: http.NoBody}
+	_, err = ResponseBody(resp)
+	assert.NoError(t, err)
+	assert.True(t, resp.Body == http.NoBody, "http.NoBody is left as is")
+}
+
+func TestBody_SharedParse(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/test", strings.NewReader(`{"a": {"b": "c"}}`))
+	assert.NoError(t, err, "failed to create test request.")
+
+	body, err := RequestBody(req)
+	assert.NoError(t, err)
+	first, err := body.JSON()
+	assert.NoError(t, err)
+	second, err := body.JSON()
+	assert.NoError(t, err)
+	first.(map[string]interface{})["x"] = "y"
+	assert.Equal(t, "y", second.(map[string]interface{})["x"], "expected the parsed body to be shared")
+}
+
+func TestBodyExtractors_NonDestructive(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/test", strings.NewReader(testXml))
+	assert.NoError(t, err, "failed to create test request.")
+
+	assert.Equal(t, "foobar", ExtractXPathString("/foo/bar/@snafu").Extract(req))
+	assert.Equal(t, "foobar", ExtractXPathString("/foo/bar/@snafu").Extract(req))
+	assert.Equal(t, testXml, ExtractBody().Extract(req))
+	content, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, testXml, string(content))
+
+	req, err = http.NewRequest("POST", "http://foo.com/test", strings.NewReader(`{"id": 5, "name": "x"}`))
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Equal(t, float64(5), ExtractJSONPath("$.id").Extract(req))
+	assert.Equal(t, "x", ExtractJSONPointer("/name").Extract(req))
+	content, err = io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id": 5, "name": "x"}`, string(content))
+}
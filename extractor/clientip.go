@@ -0,0 +1,231 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// IPSet is a set of IP addresses described by CIDR ranges and single addresses, e.g. "10.0.0.0/8", "2001:db8::/32"
+// or "192.0.2.1".  IPv4 addresses mapped into IPv6 are treated as the IPv4 addresses they map.
+type IPSet struct {
+	entries  []string
+	prefixes []netip.Prefix
+}
+
+// NewIPSet parses the entries into an IPSet.
+func NewIPSet(entries ...string) (*IPSet, error) {
+	set := &IPSet{entries: entries}
+	for _, entry := range entries {
+		var prefix netip.Prefix
+		var err error
+		if strings.Contains(entry, "/") {
+			prefix, err = netip.ParsePrefix(entry)
+		} else {
+			var addr netip.Addr
+			addr, err = netip.ParseAddr(entry)
+			if err == nil {
+				addr = addr.Unmap()
+				prefix = netip.PrefixFrom(addr, addr.BitLen())
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ip set: %v", err)
+		}
+		set.prefixes = append(set.prefixes, prefix.Masked())
+	}
+	return set, nil
+}
+
+// MustIPSet is like NewIPSet but panics if an entry can not be parsed.
+func MustIPSet(entries ...string) *IPSet {
+	set, err := NewIPSet(entries...)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// Contains returns true if the address is in one of the ranges of the set.
+func (set *IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range set.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns the entries the set was built from.
+func (set *IPSet) Entries() []string {
+	return append([]string(nil), set.entries...)
+}
+
+// String returns the entries of the set separated by commas.
+func (set *IPSet) String() string {
+	return strings.Join(set.entries, ", ")
+}
+
+// DefaultTrustedProxies are the proxies trusted by ExtractClientIP when it is called without any.  It is empty, so
+// that forwarding headers are ignored, unless it is set.
+var DefaultTrustedProxies = MustIPSet()
+
+// ExtractClientIP returns an Extractor that expects a *http.Request and returns the netip.Addr of the client that sent
+// it.  If the request's RemoteAddr is one of the trusted proxies, the addresses the proxies forwarded it for are
+// walked from the nearest to the farthest until one that is not a trusted proxy is found.  They are read from the RFC
+// 7239 Forwarded headers or, if there are none, the X-Forwarded-For headers.  The trusted proxies are IP addresses or
+// CIDR ranges.  If none are given, DefaultTrustedProxies is used.  If the address of the client is obfuscated or
+// "unknown", an invalid netip.Addr and an error wrapping ErrMissing are returned, while an address that can not be
+// parsed yields an error wrapping ErrParse.  ExtractClientIP panics if a trusted proxy can not be parsed.
+func ExtractClientIP(trustedProxies ...string) Extractor {
+	trusted, args := trustedProxySet(trustedProxies)
+	description := describe("ExtractClientIP", args...)
+	return requestExtractor("client ip", description, netip.Addr{}, func(r *http.Request) (interface{}, error) {
+		return clientIP(r, trusted())
+	})
+}
+
+// trustedProxySet returns a function returning the set of trusted proxies, DefaultTrustedProxies if there are none,
+// and the proxies as the arguments of a description.  It panics if a proxy can not be parsed.
+func trustedProxySet(trustedProxies []string) (func() *IPSet, []interface{}) {
+	args := make([]interface{}, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		args = append(args, proxy)
+	}
+	if len(trustedProxies) == 0 {
+		return func() *IPSet { return DefaultTrustedProxies }, args
+	}
+	trusted := MustIPSet(trustedProxies...)
+	return func() *IPSet { return trusted }, args
+}
+
+// fromTrustedProxy returns true if the request was sent by one of the trusted proxies.
+func fromTrustedProxy(r *http.Request, trusted *IPSet) bool {
+	addr, err := parseNode(r.RemoteAddr)
+	return err == nil && trusted.Contains(addr)
+}
+
+func clientIP(r *http.Request, trusted *IPSet) (netip.Addr, error) {
+	addr, err := parseNode(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("remote address: %w", err)
+	}
+	if !trusted.Contains(addr) {
+		return addr, nil
+	}
+	var chain []string
+	if forwarded := r.Header.Values("Forwarded"); len(forwarded) > 0 {
+		chain, err = forwardedFor(forwarded)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+	} else {
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, node := range strings.Split(header, ",") {
+				chain = append(chain, strings.TrimSpace(node))
+			}
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		addr, err = parseNode(chain[i])
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("forwarded for %q: %w", chain[i], err)
+		}
+		if !trusted.Contains(addr) {
+			return addr, nil
+		}
+	}
+	return addr, nil
+}
+
+// parseNode parses a node as found in RemoteAddr, X-Forwarded-For or the for parameter of Forwarded: an IPv4
+// address, a bracketed IPv6 address or a bare IPv6 address, with an optional port.
+func parseNode(node string) (netip.Addr, error) {
+	if node == "" || strings.EqualFold(node, "unknown") || strings.HasPrefix(node, "_") {
+		return netip.Addr{}, fmt.Errorf("%w: address is %q", ErrMissing, node)
+	}
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		node = host
+	} else if strings.HasPrefix(node, "[") && strings.HasSuffix(node, "]") {
+		node = node[1 : len(node)-1]
+	}
+	addr, err := netip.ParseAddr(node)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%w: %v", ErrParse, err)
+	}
+	return addr.Unmap(), nil
+}
+
+// forwardedFor returns the values of the for parameters of the elements of the Forwarded headers, in order.  An
+// element without a for parameter yields "unknown".
+func forwardedFor(headers []string) ([]string, error) {
+	var nodes []string
+	for _, header := range headers {
+		for _, element := range splitQuoted(header, ',') {
+			node := "unknown"
+			for _, pair := range splitQuoted(element, ';') {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					if strings.TrimSpace(pair) == "" {
+						continue
+					}
+					return nil, fmt.Errorf("%w: forwarded pair %q has no value", ErrParse, pair)
+				}
+				if strings.EqualFold(strings.TrimSpace(key), "for") {
+					node = unquote(strings.TrimSpace(value))
+				}
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// splitQuoted splits s on the separator, ignoring separators inside quoted strings.
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	quoted, escaped, start := false, false, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote removes the quotes and escapes of a quoted string.  Anything else is returned as is.
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	sb := &strings.Builder{}
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
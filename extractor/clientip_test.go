@@ -0,0 +1,106 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/netip"
+	"testing"
+)
+
+func clientIPRequest(t *testing.T, remoteAddr string, header http.Header) *http.Request {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.RemoteAddr = remoteAddr
+	req.Header = header
+	return req
+}
+
+func TestIPSet(t *testing.T) {
+	set := MustIPSet("10.0.0.0/8", "192.0.2.1", "2001:db8::/32")
+	assert.True(t, set.Contains(netip.MustParseAddr("10.1.2.3")))
+	assert.True(t, set.Contains(netip.MustParseAddr("::ffff:10.1.2.3")))
+	assert.True(t, set.Contains(netip.MustParseAddr("192.0.2.1")))
+	assert.False(t, set.Contains(netip.MustParseAddr("192.0.2.2")))
+	assert.True(t, set.Contains(netip.MustParseAddr("2001:db8::1")))
+	assert.False(t, set.Contains(netip.MustParseAddr("2001:db9::1")))
+	assert.False(t, set.Contains(netip.Addr{}))
+	assert.Equal(t, "10.0.0.0/8, 192.0.2.1, 2001:db8::/32", set.String())
+
+	_, err := NewIPSet("10.0.0.0/33")
+	assert.Error(t, err)
+	assert.Panics(t, func() { MustIPSet("not an address") })
+}
+
+func TestExtractClientIP(t *testing.T) {
+	tests := []struct {
+		Name       string
+		RemoteAddr string
+		Header     http.Header
+		Expected   string
+	}{
+		{"Remote", "203.0.113.7:1234", http.Header{}, "203.0.113.7"},
+		{"Remote IPv6", "[2001:db8::7]:1234", http.Header{}, "2001:db8::7"},
+		{"Untrusted Remote", "203.0.113.7:1234", http.Header{"X-Forwarded-For": {"198.51.100.1"}}, "203.0.113.7"},
+		{"X-Forwarded-For", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1, 10.0.0.2"}},
+			"198.51.100.1"},
+		{"X-Forwarded-For Spoofed", "10.0.0.1:1234",
+			http.Header{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1", "10.0.0.2"}}, "198.51.100.1"},
+		{"X-Forwarded-For All Trusted", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			"10.0.0.3"},
+		{"Forwarded", "10.0.0.1:1234",
+			http.Header{"Forwarded": {`for=198.51.100.1;proto=https, for="10.0.0.2:8080";by=10.0.0.1`}},
+			"198.51.100.1"},
+		{"Forwarded IPv6", "10.0.0.1:1234", http.Header{"Forwarded": {`For="[2001:db8:cafe::17]:4711"`}},
+			"2001:db8:cafe::17"},
+		{"Forwarded Quoted Comma", "10.0.0.1:1234",
+			http.Header{"Forwarded": {`for=198.51.100.1;host="a,b"`}}, "198.51.100.1"},
+		{"Forwarded Preferred", "10.0.0.1:1234",
+			http.Header{"Forwarded": {"for=198.51.100.1"}, "X-Forwarded-For": {"198.51.100.2"}}, "198.51.100.1"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			req := clientIPRequest(t, tst.RemoteAddr, tst.Header)
+			value, err := ExtractE(ExtractClientIP("10.0.0.0/8"), req)
+			if assert.NoError(t, err) {
+				assert.Equal(t, netip.MustParseAddr(tst.Expected), value)
+			}
+		})
+	}
+}
+
+func TestExtractClientIP_Errors(t *testing.T) {
+	req := clientIPRequest(t, "10.0.0.1:1234", http.Header{"Forwarded": {"for=unknown, for=10.0.0.2"}})
+	value, err := ExtractE(ExtractClientIP("10.0.0.0/8"), req)
+	assert.Equal(t, netip.Addr{}, value)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	req = clientIPRequest(t, "10.0.0.1:1234", http.Header{"Forwarded": {`for="_hidden"`}})
+	_, err = ExtractE(ExtractClientIP("10.0.0.0/8"), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	req = clientIPRequest(t, "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"not an address"}})
+	_, err = ExtractE(ExtractClientIP("10.0.0.0/8"), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+
+	req = clientIPRequest(t, "10.0.0.1:1234", http.Header{"Forwarded": {"for"}})
+	_, err = ExtractE(ExtractClientIP("10.0.0.0/8"), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+
+	_, err = ExtractE(ExtractClientIP(), clientIPRequest(t, "", http.Header{}))
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractClientIP(), "10.0.0.1")
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+	assert.Panics(t, func() { ExtractClientIP("10.0.0.0/33") })
+}
+
+func TestExtractClientIP_DefaultTrustedProxies(t *testing.T) {
+	defer func(trusted *IPSet) { DefaultTrustedProxies = trusted }(DefaultTrustedProxies)
+	req := clientIPRequest(t, "127.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1"}})
+	assert.Equal(t, netip.MustParseAddr("127.0.0.1"), ExtractClientIP().Extract(req))
+
+	DefaultTrustedProxies = MustIPSet("127.0.0.1", "::1")
+	assert.Equal(t, netip.MustParseAddr("198.51.100.1"), ExtractClientIP().Extract(req))
+	assert.Equal(t, `ExtractClientIP("10.0.0.0/8")`, Describe(ExtractClientIP("10.0.0.0/8")).String())
+}
@@ -0,0 +1,77 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ExtractCookie returns an Extractor that expects a *http.Request and returns the value of the cookie named 'name'.
+// Malformed pairs in the Cookie headers are skipped rather than failing the extraction.  If the request has no such
+// cookie, "" and an error wrapping ErrMissing are returned.
+func ExtractCookie(name string) Extractor {
+	description := describe("ExtractCookie", name)
+	return requestExtractor("cookie "+name, description, "", func(r *http.Request) (interface{}, error) {
+		cookie, err := r.Cookie(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: no cookie named %s", ErrMissing, name)
+		}
+		return cookie.Value, nil
+	})
+}
+
+// ExtractOptionalCookie returns an Extractor that expects a *http.Request and returns an Optional holding the value of
+// the cookie named 'name', or an absent Optional if the request has no such cookie.
+func ExtractOptionalCookie(name string) Extractor {
+	description := describe("ExtractOptionalCookie", name)
+	return requestExtractor("cookie "+name, description, Absent(), func(r *http.Request) (interface{}, error) {
+		cookie, err := r.Cookie(name)
+		if err != nil {
+			return Absent(), nil
+		}
+		return Present(cookie.Value), nil
+	})
+}
+
+// ExtractCookieAttributes returns an Extractor that expects a *http.Request or a *http.Response and returns the
+// *http.Cookie named 'name'.  The cookies of a request are read from its Cookie headers, which only carry names and
+// values, while the cookies of a response are read from its Set-Cookie headers, so that attributes like Path, Domain,
+// MaxAge, Secure, HttpOnly and SameSite are set as well.  Malformed cookies are skipped.  If there is no such cookie,
+// nil and an error wrapping ErrMissing are returned.
+func ExtractCookieAttributes(name string) Extractor {
+	label := "cookie " + name + " attributes"
+	description := describe("ExtractCookieAttributes", name)
+	return DescribedExtractor{Label: label, Description: description, FuncE: func(v interface{}) (interface{}, error) {
+		var cookies []*http.Cookie
+		switch m := v.(type) {
+		case *http.Request:
+			if m == nil {
+				return nil, fmt.Errorf("%w: expected a *http.Request or a *http.Response, got nil", ErrWrongType)
+			}
+			cookies = m.Cookies()
+		case *http.Response:
+			if m == nil {
+				return nil, fmt.Errorf("%w: expected a *http.Request or a *http.Response, got nil", ErrWrongType)
+			}
+			cookies = m.Cookies()
+		default:
+			return nil, fmt.Errorf("%w: expected a *http.Request or a *http.Response, got %T", ErrWrongType, v)
+		}
+		for _, cookie := range cookies {
+			if cookie.Name == name {
+				return cookie, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: no cookie named %s", ErrMissing, name)
+	}}
+}
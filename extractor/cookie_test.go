@@ -0,0 +1,59 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func cookieRequest(t *testing.T, cookie string) *http.Request {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Cookie", cookie)
+	return req
+}
+
+func TestExtractCookie(t *testing.T) {
+	req := cookieRequest(t, `session=abc123; empty=; bad cookie; flag="on"`)
+	assert.Equal(t, "abc123", ExtractCookie("session").Extract(req))
+	assert.Equal(t, "", ExtractCookie("empty").Extract(req))
+	assert.Equal(t, "on", ExtractCookie("flag").Extract(req))
+
+	value, err := ExtractE(ExtractCookie("other"), req)
+	assert.Equal(t, "", value)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	assert.Equal(t, Present(""), ExtractOptionalCookie("empty").Extract(req))
+	assert.Equal(t, Absent(), ExtractOptionalCookie("other").Extract(req))
+	assert.Equal(t, Absent(), ExtractOptionalCookie("session").Extract(cookieRequest(t, ";;=;")))
+}
+
+func TestExtractCookieAttributes(t *testing.T) {
+	req := cookieRequest(t, "session=abc123")
+	cookie, ok := ExtractCookieAttributes("session").Extract(req).(*http.Cookie)
+	if assert.True(t, ok) {
+		assert.Equal(t, "abc123", cookie.Value)
+	}
+
+	resp := &http.Response{Header: http.Header{"Set-Cookie": {
+		"session=abc123; Path=/api; Domain=foo.com; Max-Age=60; Secure; HttpOnly; SameSite=Strict",
+		"malformed",
+	}}}
+	cookie, ok = ExtractCookieAttributes("session").Extract(resp).(*http.Cookie)
+	if assert.True(t, ok) {
+		assert.Equal(t, "abc123", cookie.Value)
+		assert.Equal(t, "/api", cookie.Path)
+		assert.Equal(t, "foo.com", cookie.Domain)
+		assert.Equal(t, 60, cookie.MaxAge)
+		assert.True(t, cookie.Secure)
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+	}
+
+	_, err := ExtractE(ExtractCookieAttributes("other"), resp)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractCookieAttributes("session"), "session=abc123")
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+}
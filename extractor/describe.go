@@ -0,0 +1,68 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Description is a structured description of how an Extractor or a Predicate was built.  Name is the name of the
+// function that built it, Args are the arguments that were passed to that function and Children describe the
+// extractors and predicates it is composed of.  Tooling can walk a Description to introspect a predicate tree.
+type Description struct {
+	Name     string
+	Args     []interface{}
+	Children []Description
+}
+
+// Describer is implemented by extractors and predicates that can describe how they were built.
+type Describer interface {
+	Describe() Description
+}
+
+// Opaque is an argument of a Description that stands in for a value that must not be written out, like the keys a
+// JWT is verified with.  It is rendered between angle brackets, e.g. <keys>, and predicates whose description holds one
+// can not be encoded.
+type Opaque string
+
+// Describe returns the description of the value if it is a Describer.  Otherwise it returns a Description named after
+// the value's type.
+func Describe(v interface{}) Description {
+	if d, ok := v.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{Name: fmt.Sprintf("%T", v)}
+}
+
+// String renders the description as a function call, e.g. And(MethodIs("GET"), PathStartsWith("/api")).  Strings are
+// quoted, regular expressions are written between slashes and Opaque arguments between angle brackets.
+func (d Description) String() string {
+	params := make([]string, 0, len(d.Args)+len(d.Children))
+	for _, arg := range d.Args {
+		switch a := arg.(type) {
+		case string:
+			params = append(params, fmt.Sprintf("%q", a))
+		case *regexp.Regexp:
+			params = append(params, "/"+a.String()+"/")
+		case Opaque:
+			params = append(params, "<"+string(a)+">")
+		default:
+			params = append(params, fmt.Sprint(a))
+		}
+	}
+	for _, child := range d.Children {
+		params = append(params, child.String())
+	}
+	return d.Name + "(" + strings.Join(params, ", ") + ")"
+}
@@ -0,0 +1,54 @@
+package extractor_test
+
+import (
+	"fmt"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestDescription_String(t *testing.T) {
+	d := Description{
+		Name: "Foo",
+		Args: []interface{}{"bar", 5, regexp.MustCompile("^a+$")},
+		Children: []Description{
+			{Name: "Baz"},
+		},
+	}
+	assert.Equal(t, `Foo("bar", 5, /^a+$/, Baz())`, d.String())
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, Description{Name: "ExtractHeader", Args: []interface{}{"X-Id"}}, Describe(ExtractHeader("X-Id")))
+	assert.Equal(t, Description{Name: "ExtractPathElementByIndex", Args: []interface{}{-1}},
+		Describe(ExtractPathElementByIndex(-1)))
+	assert.Equal(t, Description{Name: "UpperCaseExtractor", Children: []Description{{Name: "ExtractMethod"}}},
+		Describe(UpperCaseExtractor(ExtractMethod())))
+	assert.Equal(t, Description{Name: "extractor.ExtractorFunc"}, Describe(ExtractorFunc(func(v interface{}) interface{} {
+		return v
+	})))
+}
+
+func TestExtractors_String(t *testing.T) {
+	tests := []struct {
+		Extractor Extractor
+		String    string
+		Label     string
+	}{
+		{IdentityExtractor(), "IdentityExtractor()", "value"},
+		{ExtractMethod(), "ExtractMethod()", "method"},
+		{ExtractPath(), "ExtractPath()", "path"},
+		{ExtractRequestURI(), "ExtractRequestURI()", "request URI"},
+		{ExtractHeader("X-Id"), `ExtractHeader("X-Id")`, "header X-Id"},
+		{ExtractHost(), "ExtractHost()", "host"},
+		{UpperCaseExtractor(ExtractHeader("X-Id")), `UpperCaseExtractor(ExtractHeader("X-Id"))`, "upper case header X-Id"},
+		{ExtractXPathString("/foo/bar"), `ExtractXPathString("/foo/bar")`, "xpath /foo/bar"},
+		{ExtractPathElementByIndex(2), "ExtractPathElementByIndex(2)", "path element 2"},
+		{ExtractQueryParameter("q"), `ExtractQueryParameter("q")`, "query parameter q"},
+	}
+	for _, tst := range tests {
+		assert.Equal(t, tst.String, fmt.Sprint(tst.Extractor))
+		assert.Equal(t, tst.Label, Label(tst.Extractor))
+	}
+}
@@ -0,0 +1,86 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrWrongType is returned when an extractor is passed a value of a type it does not expect, e.g. a
+	// *http.Response or a nil *http.Request instead of a *http.Request.
+	ErrWrongType = errors.New("wrong type")
+	// ErrMissing is returned when the value to extract does not exist, e.g. an XPath expression that selects nothing.
+	ErrMissing = errors.New("value missing")
+	// ErrParse is returned when the value can not be extracted because the input could not be parsed, e.g. a body
+	// that is not valid XML or JSON.
+	ErrParse = errors.New("parse error")
+	// ErrSignature is returned when the signature of a value, e.g. a JWT, can not be verified with any of the keys
+	// supplied.
+	ErrSignature = errors.New("invalid signature")
+)
+
+// ErrorExtractor is implemented by extractors that can report why they could not extract a value.  ExtractE returns
+// the value Extract would return along with the error.
+type ErrorExtractor interface {
+	Extractor
+	ExtractE(interface{}) (interface{}, error)
+}
+
+// ExtractE extracts the value using the extractor and returns why it could not be extracted.  If the extractor is not
+// an ErrorExtractor, a panic raised by its Extract method, like the one raised by a failed type assertion, is returned
+// as an error wrapping ErrWrongType.
+func ExtractE(extractor Extractor, v interface{}) (value interface{}, err error) {
+	if e, ok := extractor.(ErrorExtractor); ok {
+		return e.ExtractE(v)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("%w: %v", ErrWrongType, r)
+		}
+	}()
+	return extractor.Extract(v), nil
+}
+
+// requestExtractor returns a DescribedExtractor that expects a non nil *http.Request.  If it is passed anything else,
+// it returns the fallback value and an error wrapping ErrWrongType.  Otherwise it returns the result of f.
+func requestExtractor(label string, description Description, fallback interface{},
+	f func(*http.Request) (interface{}, error)) DescribedExtractor {
+	return DescribedExtractor{Label: label, Description: description, FuncE: func(v interface{}) (interface{}, error) {
+		r, ok := v.(*http.Request)
+		if !ok {
+			return fallback, fmt.Errorf("%w: expected a *http.Request, got %T", ErrWrongType, v)
+		}
+		if r == nil {
+			return fallback, fmt.Errorf("%w: expected a *http.Request, got nil", ErrWrongType)
+		}
+		return f(r)
+	}}
+}
+
+// responseExtractor returns a DescribedExtractor that expects a non nil *http.Response.  If it is passed anything
+// else, it returns the fallback value and an error wrapping ErrWrongType.  Otherwise it returns the result of f.
+func responseExtractor(label string, description Description, fallback interface{},
+	f func(*http.Response) (interface{}, error)) DescribedExtractor {
+	return DescribedExtractor{Label: label, Description: description, FuncE: func(v interface{}) (interface{}, error) {
+		resp, ok := v.(*http.Response)
+		if !ok {
+			return fallback, fmt.Errorf("%w: expected a *http.Response, got %T", ErrWrongType, v)
+		}
+		if resp == nil {
+			return fallback, fmt.Errorf("%w: expected a *http.Response, got nil", ErrWrongType)
+		}
+		return f(resp)
+	}}
+}
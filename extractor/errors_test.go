@@ -0,0 +1,80 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExtractE_WrongType(t *testing.T) {
+	var nilRequest *http.Request
+	for _, v := range []interface{}{nil, nilRequest, &http.Response{}, "GET"} {
+		value, err := ExtractE(ExtractMethod(), v)
+		assert.Equal(t, "", value)
+		assert.True(t, errors.Is(err, ErrWrongType), "%#v: %v", v, err)
+		assert.Equal(t, "", ExtractMethod().Extract(v))
+	}
+
+	value, err := ExtractE(ExtractorFunc(func(v interface{}) interface{} {
+		return v.(*http.Request).Method
+	}), &http.Response{})
+	assert.Nil(t, value)
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+
+	req, _ := http.NewRequest("GET", "http://foo.com/", nil)
+	value, err = ExtractE(ExtractorFunc(func(v interface{}) interface{} {
+		return v.(*http.Request).Method
+	}), req)
+	assert.Equal(t, "GET", value)
+	assert.NoError(t, err)
+
+	_, err = ExtractE(UpperCaseExtractor(IdentityExtractor()), 5)
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+}
+
+func TestExtractE_Missing(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/a/b", strings.NewReader(`<a><b>c</b></a>`))
+	assert.NoError(t, err, "failed to create test request.")
+	tests := []struct {
+		Extractor Extractor
+		Value     interface{}
+	}{
+		{ExtractXPathString("/a/x"), ""},
+		{ExtractPathElementByIndex(5), ""},
+		{ExtractPathParameter("/a/{x}", "x"), "b"},
+		{ExtractPathParameter("/c/{x}", "x"), ""},
+	}
+	for _, tst := range tests {
+		value, err := ExtractE(tst.Extractor, req)
+		assert.Equal(t, tst.Value, value, "%v", tst.Extractor)
+		if tst.Value == "" {
+			assert.True(t, errors.Is(err, ErrMissing), "%v: %v", tst.Extractor, err)
+		} else {
+			assert.NoError(t, err, "%v", tst.Extractor)
+		}
+	}
+
+	value, err := ExtractE(ExtractXPathString("/a/b"), req)
+	assert.Equal(t, "c", value)
+	assert.NoError(t, err)
+}
+
+func TestExtractE_Parse(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/a/b", strings.NewReader(`<a><b></a>`))
+	assert.NoError(t, err, "failed to create test request.")
+	for _, e := range []Extractor{ExtractXPathString("/a"), ExtractJSONPath("$.a"), ExtractJSONPointer("/a")} {
+		_, err := ExtractE(e, req)
+		assert.True(t, errors.Is(err, ErrParse), "%v: %v", e, err)
+	}
+
+	req, err = http.NewRequest("POST", "http://foo.com/a/b", strings.NewReader(`{"a": 1}`))
+	assert.NoError(t, err, "failed to create test request.")
+	_, err = ExtractE(ExtractJSONPath("$.b"), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	value, err := ExtractE(ExtractJSONPath("$.a"), req)
+	assert.Equal(t, 1.0, value)
+	assert.NoError(t, err)
+}
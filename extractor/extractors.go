@@ -18,6 +18,7 @@ package extractor
 // specific language governing permissions and limitations under the License.
 
 import (
+	"fmt"
 	"gopkg.in/xmlpath.v2"
 	"net/http"
 	"strings"
@@ -36,83 +37,165 @@ func (ef ExtractorFunc) Extract(v interface{}) interface{} {
 	return ef(v)
 }
 
+// DescribedExtractor is the Extractor returned by the functions in this package.  Label is a human readable name for
+// the value it extracts, e.g. "header Content-Type", that is used when explaining why a predicate rejected a value.
+// Description records how the extractor was built.  The value is extracted by FuncE if it is set and by Func otherwise.
+type DescribedExtractor struct {
+	Label       string
+	Description Description
+	Func        ExtractorFunc
+	FuncE       func(interface{}) (interface{}, error)
+}
+
+// Extract extracts the value by calling the FuncE or the Func.  The error returned by FuncE is discarded.
+func (de DescribedExtractor) Extract(v interface{}) interface{} {
+	if de.FuncE != nil {
+		value, _ := de.FuncE(v)
+		return value
+	}
+	return de.Func(v)
+}
+
+// ExtractE extracts the value by calling the FuncE or the Func and returns why it could not be extracted.
+func (de DescribedExtractor) ExtractE(v interface{}) (interface{}, error) {
+	if de.FuncE != nil {
+		return de.FuncE(v)
+	}
+	return ExtractE(de.Func, v)
+}
+
+// Describe returns the description of the extractor.
+func (de DescribedExtractor) Describe() Description {
+	return de.Description
+}
+
+// String returns the description of the extractor rendered as a function call, e.g. ExtractHeader("Content-Type").
+func (de DescribedExtractor) String() string {
+	return de.Description.String()
+}
+
+// Label returns a human readable name for the value extracted by the extractor.  It is the Label of a
+// DescribedExtractor, the String of a fmt.Stringer and "value" for anything else.
+func Label(extractor Extractor) string {
+	switch e := extractor.(type) {
+	case DescribedExtractor:
+		return e.Label
+	case fmt.Stringer:
+		return e.String()
+	}
+	return "value"
+}
+
+func describe(name string, args ...interface{}) Description {
+	return Description{Name: name, Args: args}
+}
+
 // IdentityExtractor returns an Extractor that returns the value passed.
 func IdentityExtractor() Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r
-	})
+	return DescribedExtractor{Label: "value", Description: describe("IdentityExtractor"), Func: identity}
+}
+
+func identity(v interface{}) interface{} {
+	return v
 }
 
 // ExtractMethod returns an extractor that expects a *http.Request and returns the method.
 func ExtractMethod() Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r.(*http.Request).Method
+	return requestExtractor("method", describe("ExtractMethod"), "", func(r *http.Request) (interface{}, error) {
+		return r.Method, nil
 	})
 }
 
 // ExtractPath returns an Extractor that expects a *http.Request and returns the URL's Path property.
 func ExtractPath() Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r.(*http.Request).URL.Path
+	return requestExtractor("path", describe("ExtractPath"), "", func(r *http.Request) (interface{}, error) {
+		return r.URL.Path, nil
 	})
 }
 
 // ExtractRequestURI returns an Extractor that expects a *http.Request and returns the URL's RequestURI property.
 func ExtractRequestURI() Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r.(*http.Request).URL.RequestURI()
+	return requestExtractor("request URI", describe("ExtractRequestURI"), "", func(r *http.Request) (interface{}, error) {
+		return r.URL.RequestURI(), nil
 	})
 }
 
 // ExtractHeader returns an Extractor that expects a *http.Request and returns the value of the header named 'name'.
 func ExtractHeader(name string) Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
+	description := describe("ExtractHeader", name)
+	return requestExtractor("header "+name, description, "", func(r *http.Request) (interface{}, error) {
 		if "HOST" == strings.ToUpper(name) {
-			return r.(*http.Request).Host
+			return r.Host, nil
 		}
-		return r.(*http.Request).Header.Get(name)
+		return r.Header.Get(name), nil
 	})
 }
 
 // ExtractHost returns an Extractor that returns the value of the "Host" element in the request.
 func ExtractHost() Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r.(*http.Request).Host
+	return requestExtractor("host", describe("ExtractHost"), "", func(r *http.Request) (interface{}, error) {
+		return r.Host, nil
 	})
 }
 
 // UpperCaseExtractor returns an Extractor that decorates the passed extractor by applying strings.ToUpper to the
 // value returned.
 func UpperCaseExtractor(extractor Extractor) Extractor {
-	return ExtractorFunc(func(v interface{}) interface{} {
-		value := extractor.Extract(v)
+	description := Description{Name: "UpperCaseExtractor", Children: []Description{Describe(extractor)}}
+	label := "upper case " + Label(extractor)
+	return DescribedExtractor{Label: label, Description: description, FuncE: func(v interface{}) (interface{}, error) {
+		value, err := ExtractE(extractor, v)
 		if value == nil {
-			return nil
+			return nil, err
 		}
-		return strings.ToUpper(value.(string))
-	})
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a string, got %T", ErrWrongType, value)
+		}
+		return strings.ToUpper(s), err
+	}}
 }
 
 // ExtractXPathString returns a Extractor that expects a *http.Request and uses the passed XPath expression to extract
-// a string from the Body of the request Request.
+// a string from the Body of the request Request.  The body is buffered so that it can still be read afterwards.  If
+// the body is not XML, "" and an error wrapping ErrParse are returned.  If the expression selects nothing, "" and an
+// error wrapping ErrMissing are returned.
 func ExtractXPathString(xpath string) Extractor {
 	path := xmlpath.MustCompile(xpath)
-	return ExtractorFunc(func(r interface{}) interface{} {
-		str := ""
-		root, err := xmlpath.Parse(r.(*http.Request).Body)
-		if err == nil {
-			str, _ = path.String(root)
-		}
-		return str
+	description := describe("ExtractXPathString", xpath)
+	return requestExtractor("xpath "+xpath, description, "", func(r *http.Request) (interface{}, error) {
+		return selectXPathString(path, xpath)(RequestBody(r))
 	})
 }
 
+// selectXPathString returns a function that selects a string from an XML body using the compiled XPath expression, as
+// described by ExtractXPathString.
+func selectXPathString(path *xmlpath.Path, xpath string) func(*Body, error) (interface{}, error) {
+	return func(body *Body, err error) (interface{}, error) {
+		if err != nil {
+			return "", err
+		}
+		root, err := body.XML()
+		if err != nil {
+			return "", fmt.Errorf("%w: body is not XML: %v", ErrParse, err)
+		}
+		str, ok := path.String(root)
+		if !ok {
+			return "", fmt.Errorf("%w: xpath %s selected nothing", ErrMissing, xpath)
+		}
+		return str, nil
+	}
+}
+
 // ExtractPathElementByIndex returns an Extractor that expects a *http.Request and extracts the path element at the
 // given position.  A negative number denotes a position from the end (starting at 1 e.g. -1 is the last element in the
-// path). For positive inputs, the counting starts at 1 as well.
+// path). For positive inputs, the counting starts at 1 as well.  If there is no element at the position, "" and an
+// error wrapping ErrMissing are returned.
 func ExtractPathElementByIndex(idx int) Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		elements := strings.Split(r.(*http.Request).URL.Path, "/")
+	label := fmt.Sprintf("path element %d", idx)
+	description := describe("ExtractPathElementByIndex", idx)
+	return requestExtractor(label, description, "", func(r *http.Request) (interface{}, error) {
+		elements := strings.Split(r.URL.Path, "/")
 		var i int
 		if idx < 0 {
 			i = len(elements) + idx
@@ -120,16 +203,46 @@ func ExtractPathElementByIndex(idx int) Extractor {
 			i = idx
 		}
 		if i < 0 || i >= len(elements) {
-			return ""
+			return "", fmt.Errorf("%w: path %s has no element %d", ErrMissing, r.URL.Path, idx)
 		}
-		return elements[i]
+		return elements[i], nil
+	})
+}
+
+// ExtractHeaderValues returns an Extractor that expects a *http.Request and returns a []string holding all of the
+// values of the header named 'name' in the order they were received.  Values sent as a comma separated list in a
+// single header line are not split.  If the request has no such header, an empty slice is returned.
+func ExtractHeaderValues(name string) Extractor {
+	label := "header " + name + " values"
+	description := describe("ExtractHeaderValues", name)
+	return requestExtractor(label, description, []string{}, func(r *http.Request) (interface{}, error) {
+		if "HOST" == strings.ToUpper(name) {
+			if r.Host == "" {
+				return []string{}, nil
+			}
+			return []string{r.Host}, nil
+		}
+		return append([]string{}, r.Header.Values(name)...), nil
 	})
 }
 
 // ExtractQueryParameter returns an Extractor that expects a *http.Request and extracts they named query parameter's
 // value.
 func ExtractQueryParameter(name string) Extractor {
-	return ExtractorFunc(func(r interface{}) interface{} {
-		return r.(*http.Request).URL.Query().Get(name)
+	label := "query parameter " + name
+	description := describe("ExtractQueryParameter", name)
+	return requestExtractor(label, description, "", func(r *http.Request) (interface{}, error) {
+		return r.URL.Query().Get(name), nil
+	})
+}
+
+// ExtractQueryParameterValues returns an Extractor that expects a *http.Request and returns a []string holding all of
+// the values of the named query parameter in the order they appear, e.g. ["a", "b"] for "?tag=a&tag=b".  If the
+// request has no such parameter, an empty slice is returned.
+func ExtractQueryParameterValues(name string) Extractor {
+	label := "query parameter " + name + " values"
+	description := describe("ExtractQueryParameterValues", name)
+	return requestExtractor(label, description, []string{}, func(r *http.Request) (interface{}, error) {
+		return append([]string{}, r.URL.Query()[name]...), nil
 	})
 }
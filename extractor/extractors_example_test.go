@@ -97,3 +97,10 @@ func ExampleUpperCaseExtractor() {
 	fmt.Printf("upperCase[FooBar] = %s", extractor.UpperCaseExtractor(extractor.IdentityExtractor()).Extract("FooBar"))
 	// Output: upperCase[FooBar] = FOOBAR
 }
+
+func ExampleExtractJSONPath() {
+	const testJSON = `{"items": [{"sku": "a-1", "qty": 1}, {"sku": "b-2", "qty": 3}]}`
+	req, _ := http.NewRequest("POST", "http://foo.com/test", strings.NewReader(testJSON))
+	fmt.Printf("jsonpath[$.items[*].sku] = %v", extractor.ExtractJSONPath("$.items[*].sku").Extract(req))
+	// Output: jsonpath[$.items[*].sku] = [a-1 b-2]
+}
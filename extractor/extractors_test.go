@@ -253,3 +253,20 @@ func TestExtractPathElementByIndex(t *testing.T) {
 	result = ExtractPathElementByIndex(1).Extract(request)
 	assert.Equal(t, "foo", result)
 }
+
+func TestExtractHeaderValues(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json")
+	assert.Equal(t, []string{"text/html", "application/json"}, ExtractHeaderValues("accept").Extract(req))
+	assert.Equal(t, []string{}, ExtractHeaderValues("X-Other").Extract(req))
+	assert.Equal(t, []string{"foo.com"}, ExtractHeaderValues("Host").Extract(req))
+}
+
+func TestExtractQueryParameterValues(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a?tag=a&x=1&tag=b", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Equal(t, []string{"a", "b"}, ExtractQueryParameterValues("tag").Extract(req))
+	assert.Equal(t, []string{}, ExtractQueryParameterValues("other").Extract(req))
+}
@@ -0,0 +1,205 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+)
+
+// Form is a request body parsed as an HTML form.  Values holds the fields of a urlencoded form or the parts of a
+// multipart form that are not files.  Parts holds every part of a multipart form in the order they appear.
+type Form struct {
+	Values url.Values
+	Parts  []*FormPart
+}
+
+// FormPart is a part of a multipart/form-data body.
+type FormPart struct {
+	// FieldName is the name of the form field the part holds.
+	FieldName string
+	// FileName is the name of the file the part holds, or "" if it is not a file.
+	FileName string
+	// Header holds the headers of the part.
+	Header textproto.MIMEHeader
+	// Data is the content of the part.
+	Data []byte
+}
+
+// ContentType returns the Content-Type header of the part.
+func (fp *FormPart) ContentType() string {
+	return fp.Header.Get("Content-Type")
+}
+
+// Size returns the size of the content of the part in bytes.
+func (fp *FormPart) Size() int64 {
+	return int64(len(fp.Data))
+}
+
+// Part returns the first part holding the named field, or nil if there is none.
+func (f *Form) Part(name string) *FormPart {
+	for _, part := range f.Parts {
+		if part.FieldName == name {
+			return part
+		}
+	}
+	return nil
+}
+
+func parseForm(contentType string, data []byte) (*Form, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("content type %q: %v", contentType, err)
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, err
+		}
+		return &Form{Values: values}, nil
+	case "multipart/form-data":
+		boundary := params["boundary"]
+		if boundary == "" {
+			return nil, errors.New("multipart content type has no boundary")
+		}
+		form := &Form{Values: url.Values{}}
+		reader := multipart.NewReader(bytes.NewReader(data), boundary)
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return form, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			content, err := io.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			fp := &FormPart{FieldName: part.FormName(), FileName: part.FileName(), Header: part.Header, Data: content}
+			form.Parts = append(form.Parts, fp)
+			if fp.FileName == "" {
+				form.Values.Add(fp.FieldName, string(content))
+			}
+		}
+	}
+	return nil, fmt.Errorf("content type %s is not a form", mediaType)
+}
+
+// requestForm buffers the body of the request and parses it as a form using the request's Content-Type header.
+func requestForm(r *http.Request) (*Form, error) {
+	body, err := RequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	form, err := body.Form(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: body is not a form: %v", ErrParse, err)
+	}
+	return form, nil
+}
+
+// ExtractFormField returns an Extractor that expects a *http.Request, parses its Body as an
+// application/x-www-form-urlencoded or multipart/form-data form according to its Content-Type header and returns the
+// first value of the named field.  If the body is not a form, "" and an error wrapping ErrParse are returned.  If the
+// form has no such field, "" and an error wrapping ErrMissing are returned.  The body is buffered so that it can
+// still be read afterwards.
+func ExtractFormField(name string) Extractor {
+	description := describe("ExtractFormField", name)
+	return requestExtractor("form field "+name, description, "", func(r *http.Request) (interface{}, error) {
+		form, err := requestForm(r)
+		if err != nil {
+			return "", err
+		}
+		values, ok := form.Values[name]
+		if !ok || len(values) == 0 {
+			return "", fmt.Errorf("%w: no form field named %s", ErrMissing, name)
+		}
+		return values[0], nil
+	})
+}
+
+// ExtractOptionalFormField is like ExtractFormField but returns an Optional that is absent if the form has no such
+// field.
+func ExtractOptionalFormField(name string) Extractor {
+	description := describe("ExtractOptionalFormField", name)
+	return requestExtractor("form field "+name, description, Absent(), func(r *http.Request) (interface{}, error) {
+		form, err := requestForm(r)
+		if err != nil {
+			return Absent(), err
+		}
+		values, ok := form.Values[name]
+		if !ok || len(values) == 0 {
+			return Absent(), nil
+		}
+		return Present(values[0]), nil
+	})
+}
+
+// multipartExtractor returns an extractor that applies f to the first part of a multipart/form-data body holding the
+// named field.
+func multipartExtractor(label string, description Description, fallback interface{}, name string,
+	f func(*FormPart) interface{}) Extractor {
+	return requestExtractor(label, description, fallback, func(r *http.Request) (interface{}, error) {
+		form, err := requestForm(r)
+		if err != nil {
+			return fallback, err
+		}
+		part := form.Part(name)
+		if part == nil {
+			return fallback, fmt.Errorf("%w: no multipart part named %s", ErrMissing, name)
+		}
+		return f(part), nil
+	})
+}
+
+// ExtractMultipartFileName returns an Extractor that expects a *http.Request with a multipart/form-data Body and
+// returns the file name of the first part holding the named field, or "" if the part is not a file.  If the body is
+// not a form, "" and an error wrapping ErrParse are returned.  If there is no such part, "" and an error wrapping
+// ErrMissing are returned.
+func ExtractMultipartFileName(name string) Extractor {
+	label := "multipart " + name + " file name"
+	description := describe("ExtractMultipartFileName", name)
+	return multipartExtractor(label, description, "", name, func(fp *FormPart) interface{} {
+		return fp.FileName
+	})
+}
+
+// ExtractMultipartContentType returns an Extractor that expects a *http.Request with a multipart/form-data Body and
+// returns the Content-Type header of the first part holding the named field.  Errors are reported as they are by
+// ExtractMultipartFileName.
+func ExtractMultipartContentType(name string) Extractor {
+	label := "multipart " + name + " content type"
+	description := describe("ExtractMultipartContentType", name)
+	return multipartExtractor(label, description, "", name, func(fp *FormPart) interface{} {
+		return fp.ContentType()
+	})
+}
+
+// ExtractMultipartSize returns an Extractor that expects a *http.Request with a multipart/form-data Body and returns
+// the size in bytes, as an int64, of the content of the first part holding the named field.  Errors are reported as
+// they are by ExtractMultipartFileName, with a size of 0.
+func ExtractMultipartSize(name string) Extractor {
+	label := "multipart " + name + " size"
+	description := describe("ExtractMultipartSize", name)
+	return multipartExtractor(label, description, int64(0), name, func(fp *FormPart) interface{} {
+		return fp.Size()
+	})
+}
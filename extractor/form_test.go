@@ -0,0 +1,78 @@
+package extractor_test
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func formRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest("POST", "http://foo.com/login", strings.NewReader(body))
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func multipartRequest(t *testing.T) (*http.Request, []byte) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	w.WriteField("title", "Quarterly report")
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="report.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, _ := w.CreatePart(header)
+	part.Write([]byte("%PDF-1.4"))
+	w.Close()
+	req, err := http.NewRequest("POST", "http://foo.com/upload", bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req, buf.Bytes()
+}
+
+func TestExtractFormField(t *testing.T) {
+	req := formRequest(t, "user=ann&remember=&user=bob")
+	assert.Equal(t, "ann", ExtractFormField("user").Extract(req))
+	assert.Equal(t, "", ExtractFormField("remember").Extract(req))
+	assert.Equal(t, Present(""), ExtractOptionalFormField("remember").Extract(req))
+	assert.Equal(t, Absent(), ExtractOptionalFormField("other").Extract(req))
+
+	_, err := ExtractE(ExtractFormField("other"), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, "user=ann&remember=&user=bob", string(data))
+
+	req = formRequest(t, "user=ann")
+	req.Header.Set("Content-Type", "application/json")
+	_, err = ExtractE(ExtractFormField("user"), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestExtractMultipart(t *testing.T) {
+	req, body := multipartRequest(t)
+	assert.Equal(t, "Quarterly report", ExtractFormField("title").Extract(req))
+	assert.Equal(t, "report.pdf", ExtractMultipartFileName("file").Extract(req))
+	assert.Equal(t, "", ExtractMultipartFileName("title").Extract(req))
+	assert.Equal(t, "application/pdf", ExtractMultipartContentType("file").Extract(req))
+	assert.Equal(t, int64(8), ExtractMultipartSize("file").Extract(req))
+	assert.Equal(t, "", ExtractFormField("file").Extract(req))
+
+	value, err := ExtractE(ExtractMultipartSize("other"), req)
+	assert.Equal(t, int64(0), value)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, body, data)
+
+	req = formRequest(t, "a=b")
+	req.Header.Set("Content-Type", "multipart/form-data")
+	_, err = ExtractE(ExtractMultipartFileName("file"), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
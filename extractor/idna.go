@@ -0,0 +1,31 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"golang.org/x/net/idna"
+	"strings"
+)
+
+// NormalizeHost returns the host in the form used to compare host names: the ASCII form of the name defined by IDNA
+// for lookups, i.e. mapped to lower case and NFC, with every label that is not ASCII encoded with punycode, and without
+// a trailing dot, e.g. "Bücher.Example." becomes "xn--bcher-kva.example".  Full width characters and the ideographic
+// full stops some input methods produce are mapped to their ASCII equivalents.  Hosts that are not valid domain names,
+// e.g. IP v6 addresses, are only lower cased.
+func NormalizeHost(host string) string {
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		host = ascii
+	} else {
+		host = strings.ToLower(host)
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,160 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractJSONPath returns an Extractor that expects a *http.Request, decodes its Body as JSON and returns the value
+// selected by the JSONPath expression.  Values are typed the way encoding/json decodes them into an interface{}:
+// float64, string, bool, nil, []interface{} and map[string]interface{}.  If the path can select more than one value,
+// e.g. "$.items[*].id", a []interface{} holding all of the selected values is returned.  Otherwise the selected value
+// is returned, or nil if the path did not select anything or the body is not JSON, along with an error wrapping
+// ErrMissing or ErrParse respectively.  The body is buffered so that it can still be read afterwards.
+// ExtractJSONPath panics if the expression is not valid.
+func ExtractJSONPath(path string) Extractor {
+	jp := mustCompileJSONPath(path)
+	description := describe("ExtractJSONPath", path)
+	return requestExtractor("json path "+path, description, nil, func(r *http.Request) (interface{}, error) {
+		return selectJSONPath(jp, path)(decodeJSONBody(r))
+	})
+}
+
+// selectJSONPath returns a function that selects the value of the JSONPath expression from a decoded JSON document, as
+// described by ExtractJSONPath.
+func selectJSONPath(jp *jsonPath, path string) func(interface{}, error) (interface{}, error) {
+	return func(root interface{}, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		values := jp.find(root)
+		if !jp.definite {
+			return values, nil
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: json path %s selected nothing", ErrMissing, path)
+		}
+		return values[0], nil
+	}
+}
+
+// ExtractJSONPathAll returns an Extractor that expects a *http.Request, decodes its Body as JSON and returns a
+// []interface{} holding all of the values selected by the JSONPath expression.  Unlike ExtractJSONPath, a path that
+// did not select anything can be told apart from one that selected a null.  ExtractJSONPathAll panics if the
+// expression is not valid.
+func ExtractJSONPathAll(path string) Extractor {
+	jp := mustCompileJSONPath(path)
+	label := "all json path " + path
+	description := describe("ExtractJSONPathAll", path)
+	return requestExtractor(label, description, []interface{}{}, func(r *http.Request) (interface{}, error) {
+		root, err := decodeJSONBody(r)
+		if err != nil {
+			return []interface{}{}, err
+		}
+		values := jp.find(root)
+		if values == nil {
+			values = []interface{}{}
+		}
+		return values, nil
+	})
+}
+
+// ExtractJSONPointer returns an Extractor that expects a *http.Request, decodes its Body as JSON and returns the value
+// referenced by the JSON Pointer (RFC 6901), e.g. "/items/0/id".  Values are typed as they are by ExtractJSONPath.
+// If the pointer does not reference a value or the body is not JSON, nil is returned along with an error wrapping
+// ErrMissing or ErrParse respectively.  ExtractJSONPointer panics if the pointer is not valid.
+func ExtractJSONPointer(pointer string) Extractor {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		panic(err)
+	}
+	label := "json pointer " + pointer
+	description := describe("ExtractJSONPointer", pointer)
+	return requestExtractor(label, description, nil, func(r *http.Request) (interface{}, error) {
+		root, err := decodeJSONBody(r)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := resolveJSONPointer(root, tokens)
+		if !ok {
+			return nil, fmt.Errorf("%w: json pointer %s references nothing", ErrMissing, pointer)
+		}
+		return value, nil
+	})
+}
+
+func decodeJSONBody(r *http.Request) (interface{}, error) {
+	return decodeJSON(RequestBody(r))
+}
+
+func decodeJSON(body *Body, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	value, err := body.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("%w: body is not JSON: %v", ErrParse, err)
+	}
+	return value, nil
+}
+
+// parseJSONPointer splits the pointer into its reference tokens and unescapes them.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("json pointer %q: must be empty or start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolveJSONPointer returns the value referenced by the tokens and whether it exists.
+func resolveJSONPointer(value interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) || (len(token) > 1 && token[0] == '0') {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
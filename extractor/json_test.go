@@ -0,0 +1,120 @@
+package extractor_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testJSON = `{
+  "id": "42",
+  "total": 12.5,
+  "paid": true,
+  "note": null,
+  "customer": {"name": "Ann", "tags": ["vip", "early"]},
+  "items": [
+    {"sku": "a-1", "qty": 1},
+    {"sku": "b-2", "qty": 3},
+    {"sku": "c-3", "qty": 5}
+  ],
+  "a/b": {"m~n": "escaped"},
+  "it's": "quoted"
+}`
+
+func jsonRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(body))
+	assert.NoError(t, err, "failed to create test request.")
+	return req
+}
+
+func TestExtractJSONPath(t *testing.T) {
+	tests := []struct {
+		Path     string
+		Expected interface{}
+	}{
+		{"$", nil},
+		{"$.id", "42"},
+		{"$.total", 12.5},
+		{"$.paid", true},
+		{"$.note", nil},
+		{"$.missing", nil},
+		{"$.customer.name", "Ann"},
+		{"$['customer']['name']", "Ann"},
+		{`$["customer"]["tags"][1]`, "early"},
+		{"$.customer.tags", []interface{}{"vip", "early"}},
+		{"$.items[0].sku", "a-1"},
+		{"$.items[-1].sku", "c-3"},
+		{"$.items[5].sku", nil},
+		{"$.items[*].sku", []interface{}{"a-1", "b-2", "c-3"}},
+		{"$.items.*.qty", []interface{}{float64(1), float64(3), float64(5)}},
+		{"$.items[0,2].sku", []interface{}{"a-1", "c-3"}},
+		{"$.items[1:].sku", []interface{}{"b-2", "c-3"}},
+		{"$.items[:-1].sku", []interface{}{"a-1", "b-2"}},
+		{"$.items[::2].sku", []interface{}{"a-1", "c-3"}},
+		{"$..sku", []interface{}{"a-1", "b-2", "c-3"}},
+		{"$..name", []interface{}{"Ann"}},
+		{"$..missing", []interface{}(nil)},
+		{"$.customer['name','tags'][0]", []interface{}{"vip"}},
+		{"$['a/b']['m~n']", "escaped"},
+		{`$['it\'s']`, "quoted"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Path, func(t *testing.T) {
+			result := ExtractJSONPath(tst.Path).Extract(jsonRequest(t, testJSON))
+			if tst.Path == "$" {
+				assert.IsType(t, map[string]interface{}{}, result)
+				return
+			}
+			assert.Equal(t, tst.Expected, result)
+		})
+	}
+}
+
+func TestExtractJSONPath_NotJSON(t *testing.T) {
+	assert.Nil(t, ExtractJSONPath("$.id").Extract(jsonRequest(t, "<id>42</id>")))
+	assert.Nil(t, ExtractJSONPath("$.id").Extract(&http.Request{}))
+}
+
+func TestExtractJSONPath_Invalid(t *testing.T) {
+	for _, path := range []string{"id", "$.", "$.items[", "$.items[a]", "$.items[?(@.qty > 1)]", "$.items[::0]", "$x"} {
+		assert.Panics(t, func() { ExtractJSONPath(path) }, path)
+	}
+}
+
+func TestExtractJSONPathAll(t *testing.T) {
+	req := jsonRequest(t, testJSON)
+	assert.Equal(t, []interface{}{nil}, ExtractJSONPathAll("$.note").Extract(req))
+	req = jsonRequest(t, testJSON)
+	assert.Equal(t, []interface{}{}, ExtractJSONPathAll("$.missing").Extract(req))
+	req = jsonRequest(t, testJSON)
+	assert.Equal(t, []interface{}{"42"}, ExtractJSONPathAll("$.id").Extract(req))
+	assert.Equal(t, []interface{}{}, ExtractJSONPathAll("$.id").Extract(jsonRequest(t, "not json")))
+}
+
+func TestExtractJSONPointer(t *testing.T) {
+	tests := []struct {
+		Pointer  string
+		Expected interface{}
+	}{
+		{"/id", "42"},
+		{"/total", 12.5},
+		{"/customer/name", "Ann"},
+		{"/customer/tags/0", "vip"},
+		{"/items/2/qty", float64(5)},
+		{"/items/3/qty", nil},
+		{"/items/01/qty", nil},
+		{"/items/-/qty", nil},
+		{"/a~1b/m~0n", "escaped"},
+		{"/missing", nil},
+		{"/id/deeper", nil},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Pointer, func(t *testing.T) {
+			assert.Equal(t, tst.Expected, ExtractJSONPointer(tst.Pointer).Extract(jsonRequest(t, testJSON)))
+		})
+	}
+	assert.IsType(t, map[string]interface{}{}, ExtractJSONPointer("").Extract(jsonRequest(t, testJSON)))
+	assert.Panics(t, func() { ExtractJSONPointer("id") })
+}
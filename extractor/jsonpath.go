@@ -0,0 +1,333 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled JSONPath expression.  The supported syntax is the root ($), child members by name (.name or
+// ['name']), array indexes including negative indexes from the end ([0], [-1]), wildcards (.* or [*]), recursive
+// descent (..name, ..*), unions ([0,2] or ['a','b']) and slices ([start:end:step]).  Filter expressions are not
+// supported.
+type jsonPath struct {
+	steps []jsonPathStep
+	// definite is true if the path can select at most one value, i.e. it has no wildcards, recursive descents,
+	// unions or slices.
+	definite bool
+}
+
+// jsonPathStep selects the values that a step of the path selects from a single value.
+type jsonPathStep func(value interface{}, out []interface{}) []interface{}
+
+// compileJSONPath parses the expression.
+func compileJSONPath(expr string) (*jsonPath, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("jsonpath %q: must start with '$'", expr)
+	}
+	jp := &jsonPath{definite: true}
+	rest := expr[1:]
+	for rest != "" {
+		var step jsonPathStep
+		var definite bool
+		var err error
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			rest = rest[2:]
+			var selector jsonPathStep
+			if strings.HasPrefix(rest, "[") {
+				selector, _, rest, err = parseJSONPathBracket(rest)
+			} else {
+				selector, _, rest, err = parseJSONPathName(rest)
+			}
+			step, definite = descend(selector), false
+		case strings.HasPrefix(rest, "."):
+			step, definite, rest, err = parseJSONPathName(rest[1:])
+		case strings.HasPrefix(rest, "["):
+			step, definite, rest, err = parseJSONPathBracket(rest)
+		default:
+			err = fmt.Errorf("unexpected %q", rest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jsonpath %q: %v", expr, err)
+		}
+		jp.steps = append(jp.steps, step)
+		jp.definite = jp.definite && definite
+	}
+	return jp, nil
+}
+
+// mustCompileJSONPath is like compileJSONPath but panics if the expression can not be parsed.
+func mustCompileJSONPath(expr string) *jsonPath {
+	jp, err := compileJSONPath(expr)
+	if err != nil {
+		panic(err)
+	}
+	return jp
+}
+
+// find returns all of the values selected by the path.
+func (jp *jsonPath) find(root interface{}) []interface{} {
+	values := []interface{}{root}
+	for _, step := range jp.steps {
+		var next []interface{}
+		for _, v := range values {
+			next = step(v, next)
+		}
+		values = next
+	}
+	return values
+}
+
+func parseJSONPathName(rest string) (jsonPathStep, bool, string, error) {
+	if strings.HasPrefix(rest, "*") {
+		return wildcard, false, rest[1:], nil
+	}
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+	if end == 0 {
+		return nil, false, rest, fmt.Errorf("missing member name")
+	}
+	return member(rest[:end]), true, rest[end:], nil
+}
+
+func parseJSONPathBracket(rest string) (jsonPathStep, bool, string, error) {
+	end := -1
+	quote := byte(0)
+	for i := 1; i < len(rest) && end < 0; i++ {
+		switch {
+		case quote != 0 && rest[i] == '\\':
+			i++
+		case quote != 0 && rest[i] == quote:
+			quote = 0
+		case quote == 0 && (rest[i] == '\'' || rest[i] == '"'):
+			quote = rest[i]
+		case quote == 0 && rest[i] == ']':
+			end = i
+		}
+	}
+	if end < 0 {
+		return nil, false, rest, fmt.Errorf("missing ']'")
+	}
+	body, rest := strings.TrimSpace(rest[1:end]), rest[end+1:]
+	if body == "*" {
+		return wildcard, false, rest, nil
+	}
+	var selectors []jsonPathStep
+	for _, part := range splitUnion(body) {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+			return nil, false, rest, fmt.Errorf("empty selector")
+		case part[0] == '\'' || part[0] == '"':
+			name, err := unquoteJSONPathName(part)
+			if err != nil {
+				return nil, false, rest, err
+			}
+			selectors = append(selectors, member(name))
+		case strings.Contains(part, ":"):
+			step, err := parseSlice(part)
+			if err != nil {
+				return nil, false, rest, err
+			}
+			selectors = append(selectors, step)
+		case strings.HasPrefix(part, "?"):
+			return nil, false, rest, fmt.Errorf("filter expressions are not supported")
+		default:
+			idx, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, false, rest, fmt.Errorf("invalid index %q", part)
+			}
+			selectors = append(selectors, index(idx))
+		}
+	}
+	if len(selectors) == 1 {
+		return selectors[0], !strings.Contains(body, ":"), rest, nil
+	}
+	return union(selectors), false, rest, nil
+}
+
+// splitUnion splits the body of a bracket on the commas that are not quoted.
+func splitUnion(body string) []string {
+	var parts []string
+	quote := byte(0)
+	start := 0
+	for i := 0; i < len(body); i++ {
+		switch {
+		case quote != 0 && body[i] == '\\':
+			i++
+		case quote != 0 && body[i] == quote:
+			quote = 0
+		case quote == 0 && (body[i] == '\'' || body[i] == '"'):
+			quote = body[i]
+		case quote == 0 && body[i] == ',':
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, body[start:])
+}
+
+// unquoteJSONPathName removes the quotes from a member name and replaces the escape sequences in it.
+func unquoteJSONPathName(quoted string) (string, error) {
+	if len(quoted) < 2 || quoted[len(quoted)-1] != quoted[0] {
+		return "", fmt.Errorf("unterminated name %s", quoted)
+	}
+	inner := quoted[1 : len(quoted)-1]
+	sb := strings.Builder{}
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if c == '\\' && i+1 < len(inner) {
+			i++
+			switch inner[i] {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			case 'r':
+				c = '\r'
+			default:
+				c = inner[i]
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), nil
+}
+
+func parseSlice(part string) (jsonPathStep, error) {
+	fields := strings.Split(part, ":")
+	if len(fields) > 3 {
+		return nil, fmt.Errorf("invalid slice %q", part)
+	}
+	var bounds [3]*int
+	for i, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice %q", part)
+		}
+		bounds[i] = &n
+	}
+	step := 1
+	if bounds[2] != nil {
+		step = *bounds[2]
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid slice step %d", step)
+	}
+	return func(value interface{}, out []interface{}) []interface{} {
+		array, ok := value.([]interface{})
+		if !ok {
+			return out
+		}
+		start, end := 0, len(array)
+		if bounds[0] != nil {
+			start = normalizeIndex(*bounds[0], len(array))
+		}
+		if bounds[1] != nil {
+			end = normalizeIndex(*bounds[1], len(array))
+		}
+		for i := start; i < end; i += step {
+			out = append(out, array[i])
+		}
+		return out
+	}, nil
+}
+
+// normalizeIndex converts a negative index to one from the start and clamps it to [0, length].
+func normalizeIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+func member(name string) jsonPathStep {
+	return func(value interface{}, out []interface{}) []interface{} {
+		if object, ok := value.(map[string]interface{}); ok {
+			if v, ok := object[name]; ok {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+}
+
+func index(idx int) jsonPathStep {
+	return func(value interface{}, out []interface{}) []interface{} {
+		if array, ok := value.([]interface{}); ok {
+			i := idx
+			if i < 0 {
+				i += len(array)
+			}
+			if i >= 0 && i < len(array) {
+				out = append(out, array[i])
+			}
+		}
+		return out
+	}
+}
+
+func wildcard(value interface{}, out []interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		out = append(out, v...)
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			out = append(out, v[key])
+		}
+	}
+	return out
+}
+
+func union(selectors []jsonPathStep) jsonPathStep {
+	return func(value interface{}, out []interface{}) []interface{} {
+		for _, selector := range selectors {
+			out = selector(value, out)
+		}
+		return out
+	}
+}
+
+// descend applies the selector to the value and all of its descendants.
+func descend(selector jsonPathStep) jsonPathStep {
+	var step jsonPathStep
+	step = func(value interface{}, out []interface{}) []interface{} {
+		out = selector(value, out)
+		switch v := value.(type) {
+		case []interface{}:
+			for _, child := range v {
+				out = step(child, out)
+			}
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				out = step(v[key], out)
+			}
+		}
+		return out
+	}
+	return step
+}
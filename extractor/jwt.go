@@ -0,0 +1,291 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// JWT is a decoded JSON Web Token.  Header and Claims hold the values encoding/json decodes, e.g. numbers are float64.
+type JWT struct {
+	Raw       string
+	Header    map[string]interface{}
+	Claims    map[string]interface{}
+	Signature []byte
+}
+
+// ParseJWT decodes a JWT in the compact serialization without verifying its signature.  It returns an error wrapping
+// ErrParse if the token is not made of three base64url encoded parts or if its header or claims are not JSON objects.
+func ParseJWT(token string) (*JWT, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: a JWT has 3 parts, got %d", ErrParse, len(parts))
+	}
+	jwt := &JWT{Raw: token}
+	if err := decodeJWTPart(parts[0], &jwt.Header); err != nil {
+		return nil, fmt.Errorf("%w: JWT header: %v", ErrParse, err)
+	}
+	if err := decodeJWTPart(parts[1], &jwt.Claims); err != nil {
+		return nil, fmt.Errorf("%w: JWT claims: %v", ErrParse, err)
+	}
+	var err error
+	if jwt.Signature, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return nil, fmt.Errorf("%w: JWT signature: %v", ErrParse, err)
+	}
+	return jwt, nil
+}
+
+func decodeJWTPart(part string, v *map[string]interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if *v == nil {
+		return fmt.Errorf("not a JSON object")
+	}
+	return nil
+}
+
+// Algorithm returns the alg header parameter, e.g. "HS256".
+func (jwt *JWT) Algorithm() string {
+	alg, _ := jwt.Header["alg"].(string)
+	return alg
+}
+
+// Scopes returns the scopes granted by the token: the space separated values of the scope claim or, if there is none,
+// the values of the scp claim, which may be either a space separated string or an array of strings.
+func (jwt *JWT) Scopes() []string {
+	claim, ok := jwt.Claims["scope"]
+	if !ok {
+		claim = jwt.Claims["scp"]
+	}
+	scopes := []string{}
+	switch value := claim.(type) {
+	case string:
+		scopes = append(scopes, strings.Fields(value)...)
+	case []interface{}:
+		for _, scope := range value {
+			if s, ok := scope.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	return scopes
+}
+
+// String returns the claims encoded as JSON.
+func (jwt *JWT) String() string {
+	data, err := json.Marshal(jwt.Claims)
+	if err != nil {
+		return jwt.Raw
+	}
+	return string(data)
+}
+
+var jwtHashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// Verify returns nil if the signature of the token can be verified with one of the keys.  A key is a []byte or a
+// string holding an HMAC secret for the HS256, HS384 and HS512 algorithms, an *rsa.PublicKey for RS256, RS384, RS512,
+// PS256, PS384 and PS512, or an *ecdsa.PublicKey on the P-256, P-384 or P-521 curve for ES256, ES384 and ES512
+// respectively.  Private keys are accepted in place of their public keys.  Keys that do not suit the algorithm of the
+// token are skipped.  Verify returns an error wrapping ErrSignature if no key verifies the signature or if the
+// algorithm is "none" or not supported.
+func (jwt *JWT) Verify(keys ...interface{}) error {
+	alg := jwt.Algorithm()
+	family, hash, ok := jwtAlgorithm(alg)
+	if !ok {
+		return fmt.Errorf("%w: unsupported JWT algorithm %q", ErrSignature, alg)
+	}
+	end := strings.LastIndex(jwt.Raw, ".")
+	if end < 0 {
+		return fmt.Errorf("%w: JWT has no signature", ErrSignature)
+	}
+	input := jwt.Raw[:end]
+	h := hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+	for _, key := range keys {
+		if signer, ok := key.(crypto.Signer); ok {
+			key = signer.Public()
+		}
+		var verified bool
+		switch k := key.(type) {
+		case string:
+			verified = family == "HS" && jwt.verifyHMAC(hash, input, []byte(k))
+		case []byte:
+			verified = family == "HS" && jwt.verifyHMAC(hash, input, k)
+		case *rsa.PublicKey:
+			switch family {
+			case "RS":
+				verified = rsa.VerifyPKCS1v15(k, hash, digest, jwt.Signature) == nil
+			case "PS":
+				verified = rsa.VerifyPSS(k, hash, digest, jwt.Signature, nil) == nil
+			}
+		case *ecdsa.PublicKey:
+			verified = family == "ES" && jwt.verifyECDSA(k, hash, digest)
+		}
+		if verified {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no key verifies the %s signature of the JWT", ErrSignature, alg)
+}
+
+// jwtAlgorithm splits an algorithm like "RS256" into its family, "RS", and its hash, crypto.SHA256.
+func jwtAlgorithm(alg string) (string, crypto.Hash, bool) {
+	if len(alg) != 5 {
+		return "", 0, false
+	}
+	hash, ok := jwtHashes[alg[2:]]
+	switch family := alg[:2]; family {
+	case "HS", "RS", "PS", "ES":
+		return family, hash, ok && hash.Available()
+	}
+	return "", 0, false
+}
+
+func (jwt *JWT) verifyHMAC(hash crypto.Hash, input string, secret []byte) bool {
+	mac := hmac.New(hash.New, secret)
+	mac.Write([]byte(input))
+	return hmac.Equal(mac.Sum(nil), jwt.Signature)
+}
+
+// jwtCurves are the names of the curves the ES256, ES384 and ES512 algorithms require, by hash.
+var jwtCurves = map[crypto.Hash]string{
+	crypto.SHA256: "P-256",
+	crypto.SHA384: "P-384",
+	crypto.SHA512: "P-521",
+}
+
+// verifyECDSA verifies the signature with the key, which must be on the curve the algorithm requires.
+func (jwt *JWT) verifyECDSA(key *ecdsa.PublicKey, hash crypto.Hash, digest []byte) bool {
+	if key.Curve == nil || key.Curve.Params().Name != jwtCurves[hash] {
+		return false
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	if len(jwt.Signature) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(jwt.Signature[:size])
+	s := new(big.Int).SetBytes(jwt.Signature[size:])
+	return ecdsa.Verify(key, digest, r, s)
+}
+
+// DefaultJWTKeys are the keys the JWT extractors verify tokens with when they are called without any.  It is empty, so
+// that signatures are not verified, unless it is set.  See JWT.Verify for the types of keys.
+var DefaultJWTKeys []interface{}
+
+// bearerJWT returns the JWT of the Bearer credentials of the request, verified with the keys or, if there are none,
+// with DefaultJWTKeys.
+func bearerJWT(r *http.Request, keys []interface{}) (*JWT, error) {
+	token, err := credentials(r, "Bearer")
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := ParseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		keys = DefaultJWTKeys
+	}
+	if len(keys) > 0 {
+		if err := jwt.Verify(keys...); err != nil {
+			return nil, err
+		}
+	}
+	return jwt, nil
+}
+
+// describeKeys describes an extractor that verifies tokens with the keys, which are described as the Opaque <keys>.
+func describeKeys(name string, keys []interface{}, args ...interface{}) Description {
+	if len(keys) > 0 {
+		args = append(args, Opaque("keys"))
+	}
+	return describe(name, args...)
+}
+
+// ExtractJWT returns an Extractor that expects a *http.Request and returns the *JWT of the Bearer credentials of its
+// Authorization header.  If keys are given, or DefaultJWTKeys is set, the signature of the token must be verified by
+// one of them.  It returns an error wrapping ErrMissing if there are no Bearer credentials, ErrParse if they are not a
+// JWT and ErrSignature if the signature can not be verified.  The keys are described as the Opaque <keys> so that
+// secrets do not leak into explanations and predicates verifying tokens with them can not be encoded.
+func ExtractJWT(keys ...interface{}) Extractor {
+	return requestExtractor("jwt", describeKeys("ExtractJWT", keys), nil, func(r *http.Request) (interface{}, error) {
+		return bearerJWT(r, keys)
+	})
+}
+
+// ExtractJWTClaim returns an Extractor that returns the named claim of the JWT extracted as by ExtractJWT, or nil and
+// an error wrapping ErrMissing if the token does not have the claim.
+func ExtractJWTClaim(name string, keys ...interface{}) Extractor {
+	description := describeKeys("ExtractJWTClaim", keys, name)
+	return requestExtractor("jwt claim "+name, description, nil, func(r *http.Request) (interface{}, error) {
+		jwt, err := bearerJWT(r, keys)
+		if err != nil {
+			return nil, err
+		}
+		claim, ok := jwt.Claims[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: JWT has no %s claim", ErrMissing, name)
+		}
+		return claim, nil
+	})
+}
+
+// ExtractJWTHeader returns an Extractor that returns the named header parameter of the JWT extracted as by
+// ExtractJWT, e.g. "kid", or nil and an error wrapping ErrMissing if the token does not have the parameter.
+func ExtractJWTHeader(name string, keys ...interface{}) Extractor {
+	description := describeKeys("ExtractJWTHeader", keys, name)
+	return requestExtractor("jwt header "+name, description, nil, func(r *http.Request) (interface{}, error) {
+		jwt, err := bearerJWT(r, keys)
+		if err != nil {
+			return nil, err
+		}
+		param, ok := jwt.Header[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: JWT has no %s header parameter", ErrMissing, name)
+		}
+		return param, nil
+	})
+}
+
+// ExtractJWTScopes returns an Extractor that returns the scopes, as a []string, of the JWT extracted as by
+// ExtractJWT.  See JWT.Scopes.
+func ExtractJWTScopes(keys ...interface{}) Extractor {
+	description := describeKeys("ExtractJWTScopes", keys)
+	return requestExtractor("jwt scopes", description, []string{}, func(r *http.Request) (interface{}, error) {
+		jwt, err := bearerJWT(r, keys)
+		if err != nil {
+			return []string{}, err
+		}
+		return jwt.Scopes(), nil
+	})
+}
@@ -0,0 +1,154 @@
+package extractor_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// signJWT builds a JWT with the claims signed by the key using the algorithm.
+func signJWT(t *testing.T, alg string, key interface{}, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}[alg[2:]]
+	h := hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		if alg[:2] == "PS" {
+			opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+			signature, err = rsa.SignPSS(rand.Reader, k, hash, digest, opts)
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		}
+		assert.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		assert.NoError(t, err)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestParseJWT(t *testing.T) {
+	token := signJWT(t, "HS256", []byte("secret"), map[string]interface{}{"sub": "u1", "n": 5, "scope": "read  write"})
+	jwt, err := ParseJWT(token)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "HS256", jwt.Algorithm())
+		assert.Equal(t, "u1", jwt.Claims["sub"])
+		assert.Equal(t, float64(5), jwt.Claims["n"])
+		assert.Equal(t, []string{"read", "write"}, jwt.Scopes())
+		assert.Equal(t, `{"n":5,"scope":"read  write","sub":"u1"}`, jwt.String())
+	}
+
+	jwt, err = ParseJWT(signJWT(t, "HS256", []byte("secret"), map[string]interface{}{"scp": []string{"a", "b"}}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b"}, jwt.Scopes())
+	}
+
+	for _, bad := range []string{"abc", "a.b.c", "e30.bnVsbA.", "e30.e30.!"} {
+		_, err = ParseJWT(bad)
+		assert.True(t, errors.Is(err, ErrParse), "%s: %v", bad, err)
+	}
+}
+
+func TestJWT_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		Alg      string
+		Signer   interface{}
+		Keys     []interface{}
+		Verified bool
+	}{
+		{"HS256", []byte("secret"), []interface{}{"secret"}, true},
+		{"HS512", []byte("secret"), []interface{}{[]byte("other"), []byte("secret")}, true},
+		{"HS256", []byte("secret"), []interface{}{"other"}, false},
+		{"RS256", rsaKey, []interface{}{&rsaKey.PublicKey}, true},
+		{"RS384", rsaKey, []interface{}{"secret", rsaKey}, true},
+		{"RS256", rsaKey, []interface{}{&otherRSAKey.PublicKey}, false},
+		{"PS256", rsaKey, []interface{}{&rsaKey.PublicKey}, true},
+		{"ES256", p256Key, []interface{}{&p256Key.PublicKey}, true},
+		{"ES384", p384Key, []interface{}{&p256Key.PublicKey, &p384Key.PublicKey}, true},
+		{"ES256", p256Key, []interface{}{&rsaKey.PublicKey, []byte("secret")}, false},
+		{"ES384", p256Key, []interface{}{&p256Key.PublicKey}, false},
+		{"ES256", p384Key, []interface{}{p384Key}, false},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Alg, func(t *testing.T) {
+			jwt, err := ParseJWT(signJWT(t, tst.Alg, tst.Signer, map[string]interface{}{"sub": "u1"}))
+			if assert.NoError(t, err) {
+				err = jwt.Verify(tst.Keys...)
+				if tst.Verified {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, errors.Is(err, ErrSignature), "%v", err)
+				}
+			}
+		})
+	}
+
+	unsigned, err := ParseJWT("eyJhbGciOiJub25lIn0.eyJzdWIiOiJ1MSJ9.")
+	if assert.NoError(t, err) {
+		assert.True(t, errors.Is(unsigned.Verify("secret"), ErrSignature))
+	}
+}
+
+func TestExtractJWT(t *testing.T) {
+	token := signJWT(t, "HS256", []byte("secret"), map[string]interface{}{"sub": "u1", "scope": "read"})
+	req := authRequest(t, "Bearer "+token)
+
+	jwt, ok := ExtractJWT().Extract(req).(*JWT)
+	if assert.True(t, ok) {
+		assert.Equal(t, token, jwt.Raw)
+	}
+	assert.Equal(t, "u1", ExtractJWTClaim("sub", "secret").Extract(req))
+	assert.Equal(t, "HS256", ExtractJWTHeader("alg").Extract(req))
+	assert.Equal(t, []string{"read"}, ExtractJWTScopes().Extract(req))
+
+	_, err := ExtractE(ExtractJWTClaim("sub", "other"), req)
+	assert.True(t, errors.Is(err, ErrSignature), "%v", err)
+	_, err = ExtractE(ExtractJWTClaim("aud"), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractJWTHeader("kid"), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractJWT(), authRequest(t, "Bearer opaque"))
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	assert.Equal(t, []string{}, ExtractJWTScopes().Extract(authRequest(t, "")))
+
+	defer func(keys []interface{}) { DefaultJWTKeys = keys }(DefaultJWTKeys)
+	DefaultJWTKeys = []interface{}{"other"}
+	_, err = ExtractE(ExtractJWT(), req)
+	assert.True(t, errors.Is(err, ErrSignature), "%v", err)
+	assert.Equal(t, "u1", ExtractJWTClaim("sub", "secret").Extract(req))
+	assert.Equal(t, `ExtractJWTClaim("sub", <keys>)`, Describe(ExtractJWTClaim("sub", "secret")).String())
+	assert.Equal(t, `ExtractJWTClaim("sub")`, Describe(ExtractJWTClaim("sub")).String())
+}
@@ -0,0 +1,229 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MediaType is a parsed media type or media range, e.g. "text/html; charset=utf-8" or "application/*".  Type, Subtype
+// and the names of the parameters are lower case.
+type MediaType struct {
+	Type    string
+	Subtype string
+	Params  map[string]string
+}
+
+// ParseMediaType parses a media type.  It returns an error wrapping ErrParse if the media type is not of the form
+// type/subtype followed by optional parameters.
+func ParseMediaType(s string) (MediaType, error) {
+	full, params, err := mime.ParseMediaType(s)
+	if err != nil {
+		return MediaType{}, fmt.Errorf("%w: media type %q: %v", ErrParse, s, err)
+	}
+	typ, subtype, ok := strings.Cut(full, "/")
+	if !ok || typ == "" || subtype == "" {
+		return MediaType{}, fmt.Errorf("%w: media type %q: expected type/subtype", ErrParse, s)
+	}
+	return MediaType{Type: typ, Subtype: subtype, Params: params}, nil
+}
+
+// MustParseMediaType is like ParseMediaType but panics if the media type can not be parsed.
+func MustParseMediaType(s string) MediaType {
+	mt, err := ParseMediaType(s)
+	if err != nil {
+		panic(err)
+	}
+	return mt
+}
+
+// Suffix returns the structured syntax suffix of the subtype, e.g. "json" for "application/vnd.api+json", or "" if
+// it has none.
+func (mt MediaType) Suffix() string {
+	if i := strings.LastIndex(mt.Subtype, "+"); i >= 0 {
+		return mt.Subtype[i+1:]
+	}
+	return ""
+}
+
+// Includes returns true if the media range includes the media type.  A type or subtype of "*" includes any type or
+// subtype and a subtype of "*+suffix", e.g. "*+json", includes the subtypes with that structured syntax suffix.  Every
+// parameter of the range, other than q, must be present in the media type with the same value.  The values of charset
+// parameters are compared ignoring case.
+func (mt MediaType) Includes(other MediaType) bool {
+	if mt.Type != "*" && mt.Type != other.Type {
+		return false
+	}
+	switch {
+	case mt.Subtype == "*" || mt.Subtype == other.Subtype:
+	case strings.HasPrefix(mt.Subtype, "*+"):
+		if other.Suffix() != mt.Subtype[2:] {
+			return false
+		}
+	default:
+		return false
+	}
+	for name, value := range mt.Params {
+		if name == "q" {
+			continue
+		}
+		otherValue, ok := other.Params[name]
+		if !ok || (name == "charset" && !strings.EqualFold(value, otherValue)) ||
+			(name != "charset" && value != otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the media type, e.g. "text/html; charset=utf-8".
+func (mt MediaType) String() string {
+	full := mt.Type + "/" + mt.Subtype
+	if len(mt.Params) == 0 {
+		return full
+	}
+	if formatted := mime.FormatMediaType(full, mt.Params); formatted != "" {
+		return formatted
+	}
+	return full
+}
+
+// WeightedValue is an element of a header like Accept, Accept-Language or Accept-Encoding: a value, its quality and
+// its other parameters, e.g. "text/html;level=1;q=0.5".  Value is lower case.  Q is 1 if the element has no q
+// parameter.
+type WeightedValue struct {
+	Value  string
+	Q      float64
+	Params map[string]string
+}
+
+// String formats the element, e.g. "text/html;q=0.5".  The q parameter is omitted when it is 1.
+func (wv WeightedValue) String() string {
+	s := wv.Value
+	names := make([]string, 0, len(wv.Params))
+	for name := range wv.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s += ";" + name + "=" + wv.Params[name]
+	}
+	if wv.Q != 1 {
+		s += ";q=" + strconv.FormatFloat(wv.Q, 'f', -1, 64)
+	}
+	return s
+}
+
+// WeightedValues are the elements of a header like Accept, ordered from the highest quality to the lowest.  Elements
+// of the same quality keep the order they appear in the header.
+type WeightedValues []WeightedValue
+
+// String formats the elements as a header, e.g. "text/html, */*;q=0.1".
+func (wvs WeightedValues) String() string {
+	elements := make([]string, 0, len(wvs))
+	for _, wv := range wvs {
+		elements = append(elements, wv.String())
+	}
+	return strings.Join(elements, ", ")
+}
+
+// ParseWeightedValues parses the elements of a header like Accept.  Empty elements are skipped.  It returns an error
+// wrapping ErrParse if a parameter has no value or a quality is not a number between 0 and 1.
+func ParseWeightedValues(header string) (WeightedValues, error) {
+	var wvs WeightedValues
+	for _, element := range splitQuoted(header, ',') {
+		parts := splitQuoted(element, ';')
+		wv := WeightedValue{Value: strings.ToLower(strings.TrimSpace(parts[0])), Q: 1}
+		if wv.Value == "" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: parameter %q of %q has no value", ErrParse, param, wv.Value)
+			}
+			name, value = strings.ToLower(strings.TrimSpace(name)), unquote(strings.TrimSpace(value))
+			if name == "q" {
+				q, err := strconv.ParseFloat(value, 64)
+				if err != nil || q < 0 || q > 1 {
+					return nil, fmt.Errorf("%w: quality %q of %q is not a number between 0 and 1", ErrParse, value,
+						wv.Value)
+				}
+				wv.Q = q
+				continue
+			}
+			if wv.Params == nil {
+				wv.Params = map[string]string{}
+			}
+			wv.Params[name] = value
+		}
+		wvs = append(wvs, wv)
+	}
+	sort.SliceStable(wvs, func(i, j int) bool {
+		return wvs[i].Q > wvs[j].Q
+	})
+	return wvs, nil
+}
+
+// ExtractContentType returns an Extractor that expects a *http.Request and returns its Content-Type header as a
+// MediaType.  It returns an error wrapping ErrMissing if the request has no Content-Type and one wrapping ErrParse if
+// the header is not a valid media type.
+func ExtractContentType() Extractor {
+	description := describe("ExtractContentType")
+	return requestExtractor("content type", description, MediaType{}, func(r *http.Request) (interface{}, error) {
+		header := r.Header.Get("Content-Type")
+		if header == "" {
+			return MediaType{}, fmt.Errorf("%w: no Content-Type header", ErrMissing)
+		}
+		return ParseMediaType(header)
+	})
+}
+
+// ExtractWeightedHeader returns an Extractor that expects a *http.Request and returns the elements of all of the
+// values of the named header as WeightedValues, ordered from the highest quality to the lowest.  A missing header
+// yields empty WeightedValues; it is up to the predicates to decide what that means.  It returns an error wrapping
+// ErrParse if the header can not be parsed.
+func ExtractWeightedHeader(name string) Extractor {
+	description := describe("ExtractWeightedHeader", name)
+	return requestExtractor("header "+name, description, WeightedValues{}, func(r *http.Request) (interface{}, error) {
+		wvs, err := ParseWeightedValues(strings.Join(r.Header.Values(name), ","))
+		if err != nil {
+			return WeightedValues{}, fmt.Errorf("header %s: %w", name, err)
+		}
+		if wvs == nil {
+			wvs = WeightedValues{}
+		}
+		return wvs, nil
+	})
+}
+
+// ExtractAccept returns an Extractor that returns the media ranges of the Accept header.  See ExtractWeightedHeader.
+func ExtractAccept() Extractor {
+	return ExtractWeightedHeader("Accept")
+}
+
+// ExtractAcceptLanguage returns an Extractor that returns the language ranges of the Accept-Language header.  See
+// ExtractWeightedHeader.
+func ExtractAcceptLanguage() Extractor {
+	return ExtractWeightedHeader("Accept-Language")
+}
+
+// ExtractAcceptEncoding returns an Extractor that returns the content codings of the Accept-Encoding header.  See
+// ExtractWeightedHeader.
+func ExtractAcceptEncoding() Extractor {
+	return ExtractWeightedHeader("Accept-Encoding")
+}
@@ -0,0 +1,92 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestParseMediaType(t *testing.T) {
+	mt, err := ParseMediaType(`Application/Problem+JSON; Charset="UTF-8"`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "application", mt.Type)
+		assert.Equal(t, "problem+json", mt.Subtype)
+		assert.Equal(t, "json", mt.Suffix())
+		assert.Equal(t, map[string]string{"charset": "UTF-8"}, mt.Params)
+		assert.Equal(t, "application/problem+json; charset=UTF-8", mt.String())
+	}
+
+	_, err = ParseMediaType("text")
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	_, err = ParseMediaType("text/html; charset")
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	assert.Panics(t, func() { MustParseMediaType("/") })
+}
+
+func TestMediaType_Includes(t *testing.T) {
+	tests := []struct {
+		Range    string
+		Type     string
+		Expected bool
+	}{
+		{"application/json", "application/json; charset=utf-8", true},
+		{"application/json", "application/xml", false},
+		{"application/*", "application/xml", true},
+		{"*/*", "image/png", true},
+		{"application/*+json", "application/vnd.api+json", true},
+		{"application/*+json", "application/json", false},
+		{"text/plain; charset=utf-8", "text/plain; charset=UTF-8", true},
+		{"text/plain; charset=utf-8", "text/plain", false},
+		{"text/html; level=1", "text/html; level=2", false},
+	}
+	for _, tst := range tests {
+		t.Run(tst.Range+" includes "+tst.Type, func(t *testing.T) {
+			assert.Equal(t, tst.Expected, MustParseMediaType(tst.Range).Includes(MustParseMediaType(tst.Type)))
+		})
+	}
+}
+
+func TestParseWeightedValues(t *testing.T) {
+	wvs, err := ParseWeightedValues(`text/html;q=0.5, Application/JSON, , text/plain;format="a,b";q=0, */*;q=0.1`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, WeightedValues{
+			{Value: "application/json", Q: 1},
+			{Value: "text/html", Q: 0.5},
+			{Value: "*/*", Q: 0.1},
+			{Value: "text/plain", Q: 0, Params: map[string]string{"format": "a,b"}},
+		}, wvs)
+		assert.Equal(t, "application/json, text/html;q=0.5, */*;q=0.1, text/plain;format=a,b;q=0", wvs.String())
+	}
+
+	for _, header := range []string{"gzip;q=2", "gzip;q=high", "gzip;level"} {
+		_, err = ParseWeightedValues(header)
+		assert.True(t, errors.Is(err, ErrParse), "%s: %v", header, err)
+	}
+}
+
+func TestExtractNegotiationHeaders(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	_, err = ExtractE(ExtractContentType(), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	assert.Equal(t, WeightedValues{}, ExtractAccept().Extract(req))
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json;q=0.9")
+	req.Header.Set("Accept-Language", "en-US, fr;q=0.5")
+	req.Header.Set("Accept-Encoding", "gzip;q=oops")
+
+	assert.Equal(t, MustParseMediaType("application/json; charset=utf-8"), ExtractContentType().Extract(req))
+	assert.Equal(t, "text/html, application/json;q=0.9", ExtractAccept().Extract(req).(WeightedValues).String())
+	assert.Equal(t, "en-us, fr;q=0.5", ExtractAcceptLanguage().Extract(req).(WeightedValues).String())
+	_, err = ExtractE(ExtractAcceptEncoding(), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+
+	req.Header.Set("Content-Type", "json")
+	_, err = ExtractE(ExtractContentType(), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
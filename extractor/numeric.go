@@ -0,0 +1,197 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeLayouts are the layouts AsTime parses strings with when it is not given any: RFC 3339, with or without
+// fractional seconds, and the three formats http.ParseTime accepts.
+var DefaultTimeLayouts = []string{time.RFC3339Nano, http.TimeFormat, time.RFC850, time.ANSIC}
+
+// conversionExtractor returns an Extractor that decorates the passed extractor by converting the value it returns.  If
+// the extractor returns an error, nil and the error are returned.  An empty string is missing rather than malformed,
+// so nil and an error wrapping ErrMissing are returned for it without calling convert.
+func conversionExtractor(name, kind string, extractor Extractor, args []interface{},
+	convert func(interface{}) (interface{}, error)) Extractor {
+	description := Description{Name: name, Args: args, Children: []Description{Describe(extractor)}}
+	label := Label(extractor) + " as " + kind
+	return DescribedExtractor{Label: label, Description: description, FuncE: func(v interface{}) (interface{}, error) {
+		value, err := ExtractE(extractor, v)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := value.(string); ok {
+			if s = strings.TrimSpace(s); s == "" {
+				return nil, fmt.Errorf("%w: %s is empty", ErrMissing, Label(extractor))
+			}
+			value = s
+		}
+		return convert(value)
+	}}
+}
+
+// AsInt returns an Extractor that decorates the passed extractor by converting the value it returns to an int64.
+// Strings are parsed as base 10 integers, integers of any type are converted and floats are converted if they have
+// no fractional part, e.g. the numbers returned by ExtractJSONPath.  If the value can not be converted, nil is
+// returned along with an error wrapping ErrParse, or ErrWrongType if it is neither a string nor a number.  How a
+// predicate treats these errors is up to its ErrorPolicy; the predicates of this package reject the value.
+func AsInt(extractor Extractor) Extractor {
+	return conversionExtractor("AsInt", "int", extractor, nil, func(value interface{}) (interface{}, error) {
+		if s, ok := value.(string); ok {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not an integer", ErrParse, s)
+			}
+			return i, nil
+		}
+		rv := reflect.ValueOf(value)
+		switch {
+		case rv.CanInt():
+			return rv.Int(), nil
+		case rv.CanUint():
+			if rv.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("%w: %d overflows an int64", ErrParse, rv.Uint())
+			}
+			return int64(rv.Uint()), nil
+		case rv.CanFloat():
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf("%w: %v is not an integer", ErrParse, f)
+			}
+			return int64(f), nil
+		}
+		return nil, fmt.Errorf("%w: expected a string or a number, got %T", ErrWrongType, value)
+	})
+}
+
+// AsFloat returns an Extractor that decorates the passed extractor by converting the value it returns to a float64.
+// Strings are parsed with strconv.ParseFloat and numbers of any type are converted.  Failures are reported as they are
+// by AsInt.
+func AsFloat(extractor Extractor) Extractor {
+	return conversionExtractor("AsFloat", "float", extractor, nil, func(value interface{}) (interface{}, error) {
+		if s, ok := value.(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a number", ErrParse, s)
+			}
+			return f, nil
+		}
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+		return nil, fmt.Errorf("%w: expected a string or a number, got %T", ErrWrongType, value)
+	})
+}
+
+// AsDuration returns an Extractor that decorates the passed extractor by converting the value it returns to a
+// time.Duration.  Strings are parsed with time.ParseDuration, e.g. "1m30s", except for bare numbers, which are
+// seconds like the delta-seconds of Retry-After or Cache-Control max-age, e.g. "90".  Numbers are seconds as well.
+// Failures are reported as they are by AsInt.
+func AsDuration(extractor Extractor) Extractor {
+	return conversionExtractor("AsDuration", "duration", extractor, nil, func(value interface{}) (interface{}, error) {
+		switch v := value.(type) {
+		case time.Duration:
+			return v, nil
+		case string:
+			if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+				return time.Duration(seconds * float64(time.Second)), nil
+			}
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a duration", ErrParse, v)
+			}
+			return d, nil
+		}
+		if seconds, ok := toFloat(value); ok {
+			return time.Duration(seconds * float64(time.Second)), nil
+		}
+		return nil, fmt.Errorf("%w: expected a string or a number, got %T", ErrWrongType, value)
+	})
+}
+
+// AsTime returns an Extractor that decorates the passed extractor by parsing the string it returns as a time.Time
+// using the first of the layouts that parses it.  If no layouts are given, DefaultTimeLayouts are used, which covers
+// both RFC 3339 timestamps and the dates of HTTP headers like Last-Modified.  A time.Time is returned as is.  Failures
+// are reported as they are by AsInt.
+func AsTime(extractor Extractor, layouts ...string) Extractor {
+	args := make([]interface{}, 0, len(layouts))
+	for _, layout := range layouts {
+		args = append(args, layout)
+	}
+	return conversionExtractor("AsTime", "time", extractor, args, func(value interface{}) (interface{}, error) {
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			tried := layouts
+			if len(tried) == 0 {
+				tried = DefaultTimeLayouts
+			}
+			for _, layout := range tried {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+			return nil, fmt.Errorf("%w: %q does not match any of the layouts %q", ErrParse, v, tried)
+		}
+		return nil, fmt.Errorf("%w: expected a string, got %T", ErrWrongType, value)
+	})
+}
+
+// toFloat converts a number of any type to a float64.
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// ExtractContentLength returns an Extractor that expects a *http.Request or a *http.Response and returns its
+// ContentLength as an int64.  If the length is unknown, e.g. the body is chunked, nil and an error wrapping ErrMissing
+// are returned.
+func ExtractContentLength() Extractor {
+	extract := func(v interface{}) (interface{}, error) {
+		length := int64(-2)
+		switch m := v.(type) {
+		case *http.Request:
+			if m != nil {
+				length = m.ContentLength
+			}
+		case *http.Response:
+			if m != nil {
+				length = m.ContentLength
+			}
+		}
+		switch {
+		case length == -2:
+			return nil, fmt.Errorf("%w: expected a *http.Request or a *http.Response, got %T", ErrWrongType, v)
+		case length < 0:
+			return nil, fmt.Errorf("%w: content length is unknown", ErrMissing)
+		}
+		return length, nil
+	}
+	return DescribedExtractor{Label: "content length", Description: describe("ExtractContentLength"), FuncE: extract}
+}
@@ -0,0 +1,79 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAsInt(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a?limit=42&page=x&empty=", nil)
+	assert.Equal(t, int64(42), AsInt(ExtractQueryParameter("limit")).Extract(req))
+	assert.Equal(t, "query parameter limit as int", Label(AsInt(ExtractQueryParameter("limit"))))
+	assert.Equal(t, `AsInt(ExtractQueryParameter("limit"))`, Describe(AsInt(ExtractQueryParameter("limit"))).String())
+
+	value, err := ExtractE(AsInt(ExtractQueryParameter("page")), req)
+	assert.Nil(t, value)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	_, err = ExtractE(AsInt(ExtractQueryParameter("empty")), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(AsInt(ExtractQueryParameterValues("limit")), req)
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+
+	req = httptest.NewRequest("POST", "/a", strings.NewReader(`{"count": 3, "ratio": 0.5}`))
+	assert.Equal(t, int64(3), AsInt(ExtractJSONPath("$.count")).Extract(req))
+	_, err = ExtractE(AsInt(ExtractJSONPath("$.ratio")), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestAsFloat(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a?price=9.99&count=3", nil)
+	assert.Equal(t, 9.99, AsFloat(ExtractQueryParameter("price")).Extract(req))
+	assert.Equal(t, 3.0, AsFloat(ExtractQueryParameter("count")).Extract(req))
+	assert.Equal(t, float64(7), AsFloat(ExtractorFunc(func(interface{}) interface{} { return 7 })).Extract(req))
+}
+
+func TestAsDuration(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a", nil)
+	req.Header.Set("Retry-After", "120")
+	req.Header.Set("X-Timeout", "1m30s")
+	req.Header.Set("X-Bad", "soon")
+	assert.Equal(t, 2*time.Minute, AsDuration(ExtractHeader("Retry-After")).Extract(req))
+	assert.Equal(t, 90*time.Second, AsDuration(ExtractHeader("X-Timeout")).Extract(req))
+	_, err := ExtractE(AsDuration(ExtractHeader("X-Bad")), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestAsTime(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a?since=2024-05-01T12:00:00Z&day=2024-05-01", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 12:00:00 GMT")
+	expected := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, expected.Equal(AsTime(ExtractQueryParameter("since")).Extract(req).(time.Time)))
+	assert.True(t, expected.Equal(AsTime(ExtractHeader("If-Modified-Since")).Extract(req).(time.Time)))
+	day := AsTime(ExtractQueryParameter("day"), "2006-01-02").Extract(req).(time.Time)
+	assert.True(t, expected.Add(-12*time.Hour).Equal(day))
+	assert.Equal(t, `AsTime("2006-01-02", ExtractQueryParameter("day"))`,
+		Describe(AsTime(ExtractQueryParameter("day"), "2006-01-02")).String())
+	_, err := ExtractE(AsTime(ExtractQueryParameter("day")), req)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestExtractContentLength(t *testing.T) {
+	req := httptest.NewRequest("POST", "/a", strings.NewReader("hello"))
+	assert.Equal(t, int64(5), ExtractContentLength().Extract(req))
+	req.ContentLength = -1
+	_, err := ExtractE(ExtractContentLength(), req)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Length", "12")
+	rec.WriteHeader(200)
+	assert.Equal(t, int64(12), ExtractContentLength().Extract(rec.Result()))
+
+	_, err = ExtractE(ExtractContentLength(), "a")
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+}
@@ -0,0 +1,104 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"gopkg.in/xmlpath.v2"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// Optional is a value that may be absent.  It is returned by the ExtractOptional* extractors so that a header, query
+// parameter or XPath result that is missing can be told apart from one that is empty.
+type Optional struct {
+	Value   interface{}
+	Present bool
+}
+
+// Present returns an Optional holding the value.
+func Present(value interface{}) Optional {
+	return Optional{Value: value, Present: true}
+}
+
+// Absent returns an Optional holding no value.
+func Absent() Optional {
+	return Optional{}
+}
+
+// String returns the value formatted with fmt.Sprint, or "<absent>" if there is none.
+func (o Optional) String() string {
+	if !o.Present {
+		return "<absent>"
+	}
+	return fmt.Sprint(o.Value)
+}
+
+// ExtractOptionalHeader returns an Extractor that expects a *http.Request and returns an Optional holding the first
+// value of the header named 'name', or an absent Optional if the request has no such header.  Like ExtractHeader, the
+// "Host" header is read from the request's Host field.
+func ExtractOptionalHeader(name string) Extractor {
+	description := describe("ExtractOptionalHeader", name)
+	return requestExtractor("header "+name, description, Absent(), func(r *http.Request) (interface{}, error) {
+		if "HOST" == strings.ToUpper(name) {
+			if r.Host == "" {
+				return Absent(), nil
+			}
+			return Present(r.Host), nil
+		}
+		values, ok := r.Header[textproto.CanonicalMIMEHeaderKey(name)]
+		if !ok || len(values) == 0 {
+			return Absent(), nil
+		}
+		return Present(values[0]), nil
+	})
+}
+
+// ExtractOptionalQueryParameter returns an Extractor that expects a *http.Request and returns an Optional holding the
+// first value of the named query parameter, or an absent Optional if the request has no such parameter.  A parameter
+// without a value, e.g. "?debug", is present and empty.
+func ExtractOptionalQueryParameter(name string) Extractor {
+	label := "query parameter " + name
+	description := describe("ExtractOptionalQueryParameter", name)
+	return requestExtractor(label, description, Absent(), func(r *http.Request) (interface{}, error) {
+		values, ok := r.URL.Query()[name]
+		if !ok || len(values) == 0 {
+			return Absent(), nil
+		}
+		return Present(values[0]), nil
+	})
+}
+
+// ExtractOptionalXPathString returns an Extractor that expects a *http.Request and returns an Optional holding the
+// string selected from the Body of the request by the XPath expression, or an absent Optional if the expression
+// selects nothing.  If the body is not XML, an absent Optional and an error wrapping ErrParse are returned.  The body
+// is buffered so that it can still be read afterwards.
+func ExtractOptionalXPathString(xpath string) Extractor {
+	path := xmlpath.MustCompile(xpath)
+	description := describe("ExtractOptionalXPathString", xpath)
+	return requestExtractor("xpath "+xpath, description, Absent(), func(r *http.Request) (interface{}, error) {
+		body, err := RequestBody(r)
+		if err != nil {
+			return Absent(), err
+		}
+		root, err := body.XML()
+		if err != nil {
+			return Absent(), fmt.Errorf("%w: body is not XML: %v", ErrParse, err)
+		}
+		str, ok := path.String(root)
+		if !ok {
+			return Absent(), nil
+		}
+		return Present(str), nil
+	})
+}
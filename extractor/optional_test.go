@@ -0,0 +1,54 @@
+package extractor_test
+
+import (
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExtractOptionalHeader(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header["X-Empty"] = []string{""}
+	req.Header.Add("X-Id", "1")
+	req.Header.Add("X-Id", "2")
+
+	assert.Equal(t, Present(""), ExtractOptionalHeader("X-Empty").Extract(req))
+	assert.Equal(t, Present("1"), ExtractOptionalHeader("x-id").Extract(req))
+	assert.Equal(t, Absent(), ExtractOptionalHeader("X-Other").Extract(req))
+	assert.Equal(t, Present("foo.com"), ExtractOptionalHeader("Host").Extract(req))
+}
+
+func TestExtractOptionalQueryParameter(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a?q=&debug&id=1&id=2", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Equal(t, Present(""), ExtractOptionalQueryParameter("q").Extract(req))
+	assert.Equal(t, Present(""), ExtractOptionalQueryParameter("debug").Extract(req))
+	assert.Equal(t, Present("1"), ExtractOptionalQueryParameter("id").Extract(req))
+	assert.Equal(t, Absent(), ExtractOptionalQueryParameter("other").Extract(req))
+}
+
+func TestExtractOptionalXPathString(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/a", strings.NewReader(`<a><b></b><c>d</c></a>`))
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Equal(t, Present(""), ExtractOptionalXPathString("/a/b").Extract(req))
+	assert.Equal(t, Present("d"), ExtractOptionalXPathString("/a/c").Extract(req))
+	value, err := ExtractE(ExtractOptionalXPathString("/a/x"), req)
+	assert.Equal(t, Absent(), value)
+	assert.NoError(t, err)
+
+	req, err = http.NewRequest("POST", "http://foo.com/a", strings.NewReader(`<a><b></a>`))
+	assert.NoError(t, err, "failed to create test request.")
+	value, err = ExtractE(ExtractOptionalXPathString("/a/b"), req)
+	assert.Equal(t, Absent(), value)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+}
+
+func TestOptional_String(t *testing.T) {
+	assert.Equal(t, "<absent>", Absent().String())
+	assert.Equal(t, "", Present("").String())
+	assert.Equal(t, "3", Present(3).String())
+}
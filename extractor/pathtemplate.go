@@ -0,0 +1,264 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// PathTemplate is a compiled path template, e.g. "/users/{id}/orders/{orderId:[0-9]+}".  A template is a sequence of
+// segments separated by slashes.  Within a segment:
+//
+//   - {name} matches one or more characters other than a slash and captures them as the parameter 'name'.
+//   - {name:regex} captures the characters matched by the regular expression, which must match the whole parameter.
+//   - * matches any characters other than a slash.  A segment that is just * matches exactly one non-empty segment.
+//   - any other character matches itself.
+//
+// A segment that is just ** matches zero or more whole segments, e.g. "/static/**" matches "/static" and
+// "/static/css/site.css".  The template must match the whole path.
+type PathTemplate struct {
+	template string
+	regex    *regexp.Regexp
+	names    []string
+}
+
+var pathParameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// CompilePathTemplate parses the template.
+func CompilePathTemplate(template string) (*PathTemplate, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("path template %q: must start with '/'", template)
+	}
+	segments, err := splitPathTemplate(template[1:])
+	if err != nil {
+		return nil, fmt.Errorf("path template %q: %v", template, err)
+	}
+	pt := &PathTemplate{template: template}
+	seen := map[string]bool{}
+	sb := &strings.Builder{}
+	sb.WriteString("^")
+	for _, segment := range segments {
+		if segment == "**" {
+			sb.WriteString("(?:/.*)?")
+			continue
+		}
+		sb.WriteString("/")
+		if segment == "*" {
+			sb.WriteString("[^/]+")
+			continue
+		}
+		names, err := compilePathSegment(segment, sb)
+		if err != nil {
+			return nil, fmt.Errorf("path template %q: %v", template, err)
+		}
+		for _, name := range names {
+			if seen[name] {
+				return nil, fmt.Errorf("path template %q: parameter %q is defined more than once", template, name)
+			}
+			seen[name] = true
+			pt.names = append(pt.names, name)
+		}
+	}
+	sb.WriteString("$")
+	pt.regex, err = regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("path template %q: %v", template, err)
+	}
+	return pt, nil
+}
+
+// MustCompilePathTemplate is like CompilePathTemplate but panics if the template can not be parsed.
+func MustCompilePathTemplate(template string) *PathTemplate {
+	pt, err := CompilePathTemplate(template)
+	if err != nil {
+		panic(err)
+	}
+	return pt
+}
+
+// splitPathTemplate splits the template on the slashes that are not inside braces.
+func splitPathTemplate(template string) ([]string, error) {
+	var segments []string
+	depth, start := 0, 0
+	for i, c := range template {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return nil, fmt.Errorf("unexpected '}' at offset %d", i+1)
+			}
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, template[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unterminated '{'")
+	}
+	return append(segments, template[start:]), nil
+}
+
+// compilePathSegment writes the regular expression matching the segment and returns the names of the parameters it
+// captures.
+func compilePathSegment(segment string, sb *strings.Builder) ([]string, error) {
+	var names []string
+	for segment != "" {
+		switch {
+		case strings.HasPrefix(segment, "**"):
+			return nil, fmt.Errorf("'**' must be a whole segment")
+		case segment[0] == '*':
+			sb.WriteString("[^/]*")
+			segment = segment[1:]
+		case segment[0] == '{':
+			end := closingBrace(segment)
+			name, constraint := segment[1:end], ""
+			if i := strings.Index(name, ":"); i >= 0 {
+				name, constraint = name[:i], name[i+1:]
+				if constraint == "" {
+					return nil, fmt.Errorf("parameter %q has an empty constraint", name)
+				}
+			}
+			if !pathParameterName.MatchString(name) {
+				return nil, fmt.Errorf("invalid parameter name %q", name)
+			}
+			if constraint == "" {
+				constraint = "[^/]+"
+			} else if _, err := regexp.Compile(constraint); err != nil {
+				return nil, fmt.Errorf("parameter %q: %v", name, err)
+			}
+			sb.WriteString("(?P<" + name + ">(?:" + constraint + "))")
+			names = append(names, name)
+			segment = segment[end+1:]
+		default:
+			i := strings.IndexAny(segment, "*{")
+			if i < 0 {
+				i = len(segment)
+			}
+			sb.WriteString(regexp.QuoteMeta(segment[:i]))
+			segment = segment[i:]
+		}
+	}
+	return names, nil
+}
+
+// closingBrace returns the index of the brace closing the one the segment starts with.  splitPathTemplate has
+// already checked that the braces are balanced.
+func closingBrace(segment string) int {
+	depth := 0
+	for i, c := range segment {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(segment) - 1
+}
+
+// Match returns the parameters captured from the path and true if the template matches it.
+func (pt *PathTemplate) Match(path string) (map[string]string, bool) {
+	match := pt.regex.FindStringSubmatch(path)
+	if match == nil {
+		return nil, false
+	}
+	params := make(map[string]string, len(pt.names))
+	for i, name := range pt.regex.SubexpNames() {
+		if name != "" {
+			params[name] = match[i]
+		}
+	}
+	return params, true
+}
+
+// Names returns the names of the parameters captured by the template in the order they appear.
+func (pt *PathTemplate) Names() []string {
+	return append([]string(nil), pt.names...)
+}
+
+// String returns the template.
+func (pt *PathTemplate) String() string {
+	return pt.template
+}
+
+// ExtractPathParameters returns an Extractor that expects a *http.Request and returns a map[string]string holding the
+// parameters captured from its path by the template, or nil and an error wrapping ErrMissing if the template does not
+// match the path.  ExtractPathParameters panics if the template is not valid.  See PathTemplate for the syntax of
+// templates.
+func ExtractPathParameters(template string) Extractor {
+	pt := MustCompilePathTemplate(template)
+	label := "path parameters of " + template
+	description := describe("ExtractPathParameters", template)
+	return requestExtractor(label, description, nil, func(r *http.Request) (interface{}, error) {
+		params, ok := pt.Match(r.URL.Path)
+		if !ok {
+			return nil, fmt.Errorf("%w: path %s does not match template %s", ErrMissing, r.URL.Path, template)
+		}
+		return params, nil
+	})
+}
+
+// ExtractPathParameter returns an Extractor that expects a *http.Request and returns the value of the named parameter
+// captured from its path by the template, or "" and an error wrapping ErrMissing if the template does not match the
+// path or does not capture the parameter.  ExtractPathParameter panics if the template is not valid.
+func ExtractPathParameter(template, name string) Extractor {
+	pt := MustCompilePathTemplate(template)
+	label := "path parameter " + name
+	description := describe("ExtractPathParameter", template, name)
+	return requestExtractor(label, description, "", func(r *http.Request) (interface{}, error) {
+		params, _ := pt.Match(r.URL.Path)
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("%w: path %s does not capture %s using template %s", ErrMissing, r.URL.Path, name,
+				template)
+		}
+		return value, nil
+	})
+}
+
+type pathParametersKey struct{}
+
+// WithPathParameters returns a shallow copy of the request whose context carries the path parameters, merged with any
+// parameters the request's context already carries.  Handlers read them back with PathParameters.
+func WithPathParameters(r *http.Request, params map[string]string) *http.Request {
+	merged := make(map[string]string, len(params))
+	for name, value := range PathParameters(r) {
+		merged[name] = value
+	}
+	for name, value := range params {
+		merged[name] = value
+	}
+	return r.WithContext(context.WithValue(r.Context(), pathParametersKey{}, merged))
+}
+
+// PathParameters returns the path parameters stored in the request's context by WithPathParameters, or nil if there
+// are none.
+func PathParameters(r *http.Request) map[string]string {
+	params, _ := r.Context().Value(pathParametersKey{}).(map[string]string)
+	return params
+}
+
+// PathParameter returns the named path parameter stored in the request's context, or "" if there is none.
+func PathParameter(r *http.Request, name string) string {
+	return PathParameters(r)[name]
+}
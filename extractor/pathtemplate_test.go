@@ -0,0 +1,96 @@
+package extractor_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestPathTemplate_Match(t *testing.T) {
+	tests := []struct {
+		Template string
+		Path     string
+		Params   map[string]string
+	}{
+		{"/", "/", map[string]string{}},
+		{"/users", "/users", map[string]string{}},
+		{"/users", "/users/", nil},
+		{"/users/{id}", "/users/42", map[string]string{"id": "42"}},
+		{"/users/{id}", "/users/", nil},
+		{"/users/{id}", "/users/42/orders", nil},
+		{"/users/{id}/orders/{orderId:[0-9]+}", "/users/ann/orders/7", map[string]string{"id": "ann", "orderId": "7"}},
+		{"/users/{id}/orders/{orderId:[0-9]+}", "/users/ann/orders/x7", nil},
+		{"/codes/{code:[A-Z]{3}}", "/codes/ABC", map[string]string{"code": "ABC"}},
+		{"/codes/{code:[A-Z]{3}}", "/codes/ABCD", nil},
+		{"/files/{name}.{ext}", "/files/report.pdf", map[string]string{"name": "report", "ext": "pdf"}},
+		{"/a/*/c", "/a/b/c", map[string]string{}},
+		{"/a/*/c", "/a//c", nil},
+		{"/a/*/c", "/a/b/b/c", nil},
+		{"/files/*.json", "/files/a.json", map[string]string{}},
+		{"/static/**", "/static", map[string]string{}},
+		{"/static/**", "/static/css/site.css", map[string]string{}},
+		{"/static/**", "/statics", nil},
+		{"/a/**/{name}", "/a/b", map[string]string{"name": "b"}},
+		{"/a/**/{name}", "/a/x/y/b", map[string]string{"name": "b"}},
+		{"/a.b", "/axb", nil},
+	}
+	for _, tst := range tests {
+		params, ok := MustCompilePathTemplate(tst.Template).Match(tst.Path)
+		assert.Equal(t, tst.Params != nil, ok, "%s %s", tst.Template, tst.Path)
+		assert.Equal(t, tst.Params, params, "%s %s", tst.Template, tst.Path)
+	}
+}
+
+func TestCompilePathTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		Template string
+		Error    string
+	}{
+		{"users", "must start with '/'"},
+		{"/users/{id", "unterminated '{'"},
+		{"/users/id}", "unexpected '}'"},
+		{"/users/{}", `invalid parameter name ""`},
+		{"/users/{1d}", `invalid parameter name "1d"`},
+		{"/users/{id:}", "empty constraint"},
+		{"/users/{id:(}", `parameter "id"`},
+		{"/users/{id}/{id}", `parameter "id" is defined more than once`},
+		{"/files/**.json", "'**' must be a whole segment"},
+	}
+	for _, tst := range tests {
+		_, err := CompilePathTemplate(tst.Template)
+		if assert.Error(t, err, tst.Template) {
+			assert.Contains(t, err.Error(), tst.Error)
+		}
+	}
+	assert.Panics(t, func() { ExtractPathParameters("users") })
+}
+
+func TestPathTemplate_Names(t *testing.T) {
+	pt := MustCompilePathTemplate("/users/{id}/orders/{orderId:[0-9]+}")
+	assert.Equal(t, []string{"id", "orderId"}, pt.Names())
+	assert.Equal(t, "/users/{id}/orders/{orderId:[0-9]+}", pt.String())
+}
+
+func TestExtractPathParameters(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/users/ann/orders/7?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Equal(t, map[string]string{"id": "ann", "orderId": "7"},
+		ExtractPathParameters("/users/{id}/orders/{orderId}").Extract(req))
+	assert.Nil(t, ExtractPathParameters("/orders/{orderId}").Extract(req))
+	assert.Equal(t, "7", ExtractPathParameter("/users/{id}/orders/{orderId}", "orderId").Extract(req))
+	assert.Equal(t, "", ExtractPathParameter("/orders/{orderId}", "orderId").Extract(req))
+	assert.Equal(t, `ExtractPathParameter("/users/{id}", "id")`,
+		ExtractPathParameter("/users/{id}", "id").(DescribedExtractor).String())
+}
+
+func TestWithPathParameters(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/users/ann", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.Nil(t, PathParameters(req))
+	req = WithPathParameters(req, map[string]string{"id": "ann"})
+	req = WithPathParameters(req, map[string]string{"orderId": "7"})
+	assert.Equal(t, map[string]string{"id": "ann", "orderId": "7"}, PathParameters(req))
+	assert.Equal(t, "ann", PathParameter(req, "id"))
+	assert.Equal(t, "", PathParameter(req, "other"))
+}
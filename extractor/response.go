@@ -0,0 +1,111 @@
+package extractor
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"gopkg.in/xmlpath.v2"
+	"net/http"
+)
+
+// ExtractStatusCode returns an Extractor that expects a *http.Response and returns its StatusCode as an int.
+func ExtractStatusCode() Extractor {
+	description := describe("ExtractStatusCode")
+	return responseExtractor("status code", description, 0, func(resp *http.Response) (interface{}, error) {
+		return resp.StatusCode, nil
+	})
+}
+
+// ExtractStatusClass returns an Extractor that expects a *http.Response and returns the class of its StatusCode, e.g.
+// "2xx" for 204.  If the status code is not between 100 and 999, "" and an error wrapping ErrParse are returned.
+func ExtractStatusClass() Extractor {
+	description := describe("ExtractStatusClass")
+	return responseExtractor("status class", description, "", func(resp *http.Response) (interface{}, error) {
+		if resp.StatusCode < 100 || resp.StatusCode > 999 {
+			return "", fmt.Errorf("%w: %d is not a status code", ErrParse, resp.StatusCode)
+		}
+		return fmt.Sprintf("%dxx", resp.StatusCode/100), nil
+	})
+}
+
+// ExtractResponseHeader returns an Extractor that expects a *http.Response and returns the value of the header named
+// 'name', or "" if the response does not have the header.
+func ExtractResponseHeader(name string) Extractor {
+	description := describe("ExtractResponseHeader", name)
+	return responseExtractor("response header "+name, description, "", func(resp *http.Response) (interface{}, error) {
+		return resp.Header.Get(name), nil
+	})
+}
+
+// ExtractResponseHeaderValues returns an Extractor that expects a *http.Response and returns all of the values of the
+// header named 'name' as a []string.
+func ExtractResponseHeaderValues(name string) Extractor {
+	label := "response header " + name + " values"
+	description := describe("ExtractResponseHeaderValues", name)
+	return responseExtractor(label, description, []string{}, func(resp *http.Response) (interface{}, error) {
+		return append([]string{}, resp.Header.Values(name)...), nil
+	})
+}
+
+// ExtractTrailer returns an Extractor that expects a *http.Response and returns the value of the trailer named 'name'.
+// Trailers are only known once the body has been read, so the body is buffered first, as by ResponseBody.  If the
+// response does not have the trailer, "" and an error wrapping ErrMissing are returned.
+func ExtractTrailer(name string) Extractor {
+	description := describe("ExtractTrailer", name)
+	return responseExtractor("trailer "+name, description, "", func(resp *http.Response) (interface{}, error) {
+		if _, err := ResponseBody(resp); err != nil {
+			return "", err
+		}
+		values := resp.Trailer.Values(name)
+		if len(values) == 0 {
+			return "", fmt.Errorf("%w: response has no %s trailer", ErrMissing, name)
+		}
+		return values[0], nil
+	})
+}
+
+// ExtractResponseBody returns an Extractor that expects a *http.Response and returns its body as a string.  The body
+// is buffered so that it can still be read afterwards.  If the body can not be buffered, "" and the error returned by
+// ResponseBody are returned.
+func ExtractResponseBody() Extractor {
+	description := describe("ExtractResponseBody")
+	return responseExtractor("response body", description, "", func(resp *http.Response) (interface{}, error) {
+		body, err := ResponseBody(resp)
+		if err != nil {
+			return "", err
+		}
+		data, _ := body.Bytes()
+		return string(data), nil
+	})
+}
+
+// ExtractResponseXPathString returns an Extractor that expects a *http.Response and uses the XPath expression to
+// extract a string from its body, as ExtractXPathString does for requests.
+func ExtractResponseXPathString(xpath string) Extractor {
+	path := xmlpath.MustCompile(xpath)
+	label := "response xpath " + xpath
+	description := describe("ExtractResponseXPathString", xpath)
+	return responseExtractor(label, description, "", func(resp *http.Response) (interface{}, error) {
+		return selectXPathString(path, xpath)(ResponseBody(resp))
+	})
+}
+
+// ExtractResponseJSONPath returns an Extractor that expects a *http.Response, decodes its body as JSON and returns the
+// value selected by the JSONPath expression, as ExtractJSONPath does for requests.
+func ExtractResponseJSONPath(path string) Extractor {
+	jp := mustCompileJSONPath(path)
+	label := "response json path " + path
+	description := describe("ExtractResponseJSONPath", path)
+	return responseExtractor(label, description, nil, func(resp *http.Response) (interface{}, error) {
+		return selectJSONPath(jp, path)(decodeJSON(ResponseBody(resp)))
+	})
+}
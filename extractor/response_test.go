@@ -0,0 +1,87 @@
+package extractor_test
+
+import (
+	"bufio"
+	"errors"
+	. "github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newResponse(status int, contentType, body string) *http.Response {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", contentType)
+	rec.Header().Add("Vary", "Accept")
+	rec.Header().Add("Vary", "Accept-Encoding")
+	rec.WriteHeader(status)
+	rec.WriteString(body)
+	return rec.Result()
+}
+
+func TestExtractStatus(t *testing.T) {
+	resp := newResponse(204, "text/plain", "")
+	assert.Equal(t, 204, ExtractStatusCode().Extract(resp))
+	assert.Equal(t, "2xx", ExtractStatusClass().Extract(resp))
+	resp.StatusCode = 42
+	_, err := ExtractE(ExtractStatusClass(), resp)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+
+	_, err = ExtractE(ExtractStatusCode(), httptest.NewRequest("GET", "/", nil))
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+	_, err = ExtractE(ExtractStatusCode(), (*http.Response)(nil))
+	assert.True(t, errors.Is(err, ErrWrongType), "%v", err)
+}
+
+func TestExtractResponseHeader(t *testing.T) {
+	resp := newResponse(200, "application/json", "{}")
+	assert.Equal(t, "application/json", ExtractResponseHeader("content-type").Extract(resp))
+	assert.Equal(t, "", ExtractResponseHeader("ETag").Extract(resp))
+	assert.Equal(t, []string{"Accept", "Accept-Encoding"}, ExtractResponseHeaderValues("Vary").Extract(resp))
+	assert.Equal(t, "response header Vary values", Label(ExtractResponseHeaderValues("Vary")))
+}
+
+func TestExtractTrailer(t *testing.T) {
+	raw := "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\nTrailer: Grpc-Status\r\n\r\n" +
+		"5\r\nhello\r\n0\r\nGrpc-Status: 0\r\n\r\n"
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(raw)), nil)
+	assert.NoError(t, err, "failed to read test response.")
+	assert.Equal(t, "0", ExtractTrailer("Grpc-Status").Extract(resp))
+	_, err = ExtractE(ExtractTrailer("Grpc-Message"), resp)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	data, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestExtractResponseBody(t *testing.T) {
+	resp := newResponse(200, "application/xml", "<order><id>7</id></order>")
+	assert.Equal(t, "<order><id>7</id></order>", ExtractResponseBody().Extract(resp))
+	assert.Equal(t, "7", ExtractResponseXPathString("/order/id").Extract(resp))
+	_, err := ExtractE(ExtractResponseXPathString("/order/total"), resp)
+	assert.True(t, errors.Is(err, ErrMissing), "%v", err)
+	_, err = ExtractE(ExtractResponseJSONPath("$.id"), resp)
+	assert.True(t, errors.Is(err, ErrParse), "%v", err)
+	data, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "<order><id>7</id></order>", string(data))
+
+	resp = newResponse(200, "application/json", `{"items": [{"id": 1}, {"id": 2}]}`)
+	assert.Equal(t, float64(2), ExtractResponseJSONPath("$.items[1].id").Extract(resp))
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, ExtractResponseJSONPath("$.items[*].id").Extract(resp))
+	assert.Equal(t, `ExtractResponseJSONPath("$.items[1].id")`,
+		Describe(ExtractResponseJSONPath("$.items[1].id")).String())
+}
+
+func TestBufferResponseBody(t *testing.T) {
+	resp := newResponse(200, "text/plain", "0123456789")
+	body, err := BufferResponseBody(resp, 4)
+	assert.Equal(t, ErrBodyTooLarge, err)
+	assert.True(t, body == resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+}
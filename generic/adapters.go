@@ -0,0 +1,57 @@
+package generic
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+)
+
+// ToPredicate adapts a Predicate[T] to a predicate.Predicate.  Values that are not of type T are rejected rather than
+// causing a panic.
+func ToPredicate[T any](p Predicate[T]) predicate.Predicate {
+	return predicate.PredicateFunc(func(v interface{}) bool {
+		t, ok := v.(T)
+		if !ok {
+			return false
+		}
+		return p.Accept(t)
+	})
+}
+
+// FromPredicate adapts a predicate.Predicate to a Predicate[T].  The value is passed to the wrapped predicate as is.
+func FromPredicate[T any](p predicate.Predicate) Predicate[T] {
+	return PredicateFunc[T](func(v T) bool {
+		return p.Accept(v)
+	})
+}
+
+// ToExtractor adapts an Extractor[In, Out] to an extractor.Extractor.  If the value passed is not of type In, the
+// returned extractor returns nil.
+func ToExtractor[In, Out any](e Extractor[In, Out]) extractor.Extractor {
+	return extractor.ExtractorFunc(func(v interface{}) interface{} {
+		in, ok := v.(In)
+		if !ok {
+			return nil
+		}
+		return e.Extract(in)
+	})
+}
+
+// FromExtractor adapts an extractor.Extractor to an Extractor[In, Out].  If the wrapped extractor returns a value that
+// is not of type Out, the zero value of Out is returned.
+func FromExtractor[In, Out any](e extractor.Extractor) Extractor[In, Out] {
+	return ExtractorFunc[In, Out](func(v In) Out {
+		out, _ := e.Extract(v).(Out)
+		return out
+	})
+}
@@ -0,0 +1,57 @@
+package generic_test
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/generic"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestToPredicate(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	p := ToPredicate(MethodIs("GET"))
+	assert.True(t, p.Accept(req))
+	assert.False(t, p.Accept("GET"), "values of the wrong type should be rejected")
+	assert.True(t, predicate.And(p, predicate.PathEquals("/test/foo/bar")).Accept(req))
+}
+
+func TestFromPredicate(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	p := And(FromPredicate[*http.Request](predicate.QueryParamEquals("q", "5")), MethodIs("GET"))
+	assert.True(t, p.Accept(req))
+	assert.False(t, And(p, FromPredicate[*http.Request](predicate.False())).Accept(req))
+}
+
+func TestToExtractor(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	e := ToExtractor(ExtractPath())
+	assert.Equal(t, "/test/foo/bar", e.Extract(req))
+	assert.Nil(t, e.Extract("not a request"))
+}
+
+func TestFromExtractor(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	assert.Equal(t, "5", FromExtractor[*http.Request, string](extractor.ExtractQueryParameter("q")).Extract(req))
+	assert.Equal(t, 0, FromExtractor[*http.Request, int](extractor.ExtractQueryParameter("q")).Extract(req))
+}
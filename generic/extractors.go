@@ -0,0 +1,134 @@
+// Package generic mirrors the extractor and predicate packages using type parameters.  Where the original packages
+// pass interface{} values around and rely on type assertions at runtime, the types in this package carry their input
+// and output types so that mismatched compositions, like passing a *http.Request to a Predicate[string], are caught by
+// the compiler.  The ToPredicate, FromPredicate, ToExtractor and FromExtractor adapters convert between the generic
+// and the interface{} based forms so that the two can be mixed freely.
+package generic
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"gopkg.in/xmlpath.v2"
+	"net/http"
+	"strings"
+)
+
+// Extractor can extract a value of type Out from a value of type In by calling the Extract method.
+type Extractor[In, Out any] interface {
+	Extract(In) Out
+}
+
+// ExtractorFunc is a function that calls itself when it's Extract method is called.
+type ExtractorFunc[In, Out any] func(In) Out
+
+// Extract extracts the value by calling the ExtractorFunc
+func (ef ExtractorFunc[In, Out]) Extract(v In) Out {
+	return ef(v)
+}
+
+// IdentityExtractor returns an Extractor that returns the value passed.
+func IdentityExtractor[T any]() Extractor[T, T] {
+	return ExtractorFunc[T, T](func(v T) T {
+		return v
+	})
+}
+
+// ExtractMethod returns an extractor that returns the method of the request.
+func ExtractMethod() Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		return r.Method
+	})
+}
+
+// ExtractPath returns an Extractor that returns the URL's Path property.
+func ExtractPath() Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		return r.URL.Path
+	})
+}
+
+// ExtractRequestURI returns an Extractor that returns the URL's RequestURI property.
+func ExtractRequestURI() Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		return r.URL.RequestURI()
+	})
+}
+
+// ExtractHeader returns an Extractor that returns the value of the header named 'name'.
+func ExtractHeader(name string) Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		if "HOST" == strings.ToUpper(name) {
+			return r.Host
+		}
+		return r.Header.Get(name)
+	})
+}
+
+// ExtractHost returns an Extractor that returns the value of the "Host" element in the request.
+func ExtractHost() Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		return r.Host
+	})
+}
+
+// UpperCaseExtractor returns an Extractor that decorates the passed extractor by applying strings.ToUpper to the
+// value returned.
+func UpperCaseExtractor[In any](extractor Extractor[In, string]) Extractor[In, string] {
+	return ExtractorFunc[In, string](func(v In) string {
+		return strings.ToUpper(extractor.Extract(v))
+	})
+}
+
+// ExtractXPathString returns a Extractor that uses the passed XPath expression to extract a string from the Body of
+// the request.  The body is buffered so that it can still be read afterwards.
+func ExtractXPathString(xpath string) Extractor[*http.Request, string] {
+	path := xmlpath.MustCompile(xpath)
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		str := ""
+		body, err := extractor.RequestBody(r)
+		if err != nil {
+			return str
+		}
+		root, err := body.XML()
+		if err == nil {
+			str, _ = path.String(root)
+		}
+		return str
+	})
+}
+
+// ExtractPathElementByIndex returns an Extractor that extracts the path element at the given position.  A negative
+// number denotes a position from the end (starting at 1 e.g. -1 is the last element in the path). For positive inputs,
+// the counting starts at 1 as well.
+func ExtractPathElementByIndex(idx int) Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		elements := strings.Split(r.URL.Path, "/")
+		var i int
+		if idx < 0 {
+			i = len(elements) + idx
+		} else {
+			i = idx
+		}
+		if i < 0 || i >= len(elements) {
+			return ""
+		}
+		return elements[i]
+	})
+}
+
+// ExtractQueryParameter returns an Extractor that extracts the named query parameter's value.
+func ExtractQueryParameter(name string) Extractor[*http.Request, string] {
+	return ExtractorFunc[*http.Request, string](func(r *http.Request) string {
+		return r.URL.Query().Get(name)
+	})
+}
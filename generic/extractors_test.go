@@ -0,0 +1,64 @@
+package generic_test
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	. "github.com/danapsimer/go-http-matchers/generic"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExtractHeader(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("Foo", "Bar")
+
+	assert.Equal(t, "Bar", ExtractHeader("Foo").Extract(req))
+	assert.Equal(t, "foo.com", ExtractHeader("host").Extract(req))
+	assert.Equal(t, "", ExtractHeader("Snafu").Extract(req))
+}
+
+func TestExtractRequestParts(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	assert.Equal(t, "GET", ExtractMethod().Extract(req))
+	assert.Equal(t, "/test/foo/bar", ExtractPath().Extract(req))
+	assert.Equal(t, "/test/foo/bar?q=5&l=3", ExtractRequestURI().Extract(req))
+	assert.Equal(t, "foo.com", ExtractHost().Extract(req))
+	assert.Equal(t, "5", ExtractQueryParameter("q").Extract(req))
+	assert.Equal(t, "", ExtractQueryParameter("z").Extract(req))
+	assert.True(t, req == IdentityExtractor[*http.Request]().Extract(req))
+}
+
+func TestExtractPathElementByIndex(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	assert.Equal(t, "test", ExtractPathElementByIndex(1).Extract(req))
+	assert.Equal(t, "bar", ExtractPathElementByIndex(-1).Extract(req))
+	assert.Equal(t, "", ExtractPathElementByIndex(4).Extract(req))
+	assert.Equal(t, "", ExtractPathElementByIndex(-5).Extract(req))
+}
+
+func TestUpperCaseExtractor(t *testing.T) {
+	assert.Equal(t, "FOOBAR", UpperCaseExtractor(IdentityExtractor[string]()).Extract("FooBar"))
+}
+
+func TestExtractXPathString(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/test", strings.NewReader(`<foo><bar snafu="fubar"/></foo>`))
+	assert.NoError(t, err, "failed to create test request.")
+
+	assert.Equal(t, "fubar", ExtractXPathString("/foo/bar/@snafu").Extract(req))
+}
@@ -0,0 +1,87 @@
+package generic
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Predicate is a class that can accept or reject a value of type T based on some condition.
+type Predicate[T any] interface {
+	Accept(T) bool
+}
+
+// PredicateFunc is an implementation of Predicate that is a function and calls itself on a call to Accept
+type PredicateFunc[T any] func(T) bool
+
+// Accept calls the predicate func with the passed value.
+func (pf PredicateFunc[T]) Accept(v T) bool {
+	return pf(v)
+}
+
+// And returns a predicate that is true if all of the passed predicate are true for the input.  Furthermore, it stops
+// executing predicates after the first false one.
+func And[T any](predicates ...Predicate[T]) Predicate[T] {
+	return PredicateFunc[T](func(v T) bool {
+		for _, p := range predicates {
+			if !p.Accept(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Or returns a predicate that is true if any of the passed predicate are true.  Furthermore, it stops executing
+// predicates after the first true one.
+func Or[T any](predicates ...Predicate[T]) Predicate[T] {
+	return PredicateFunc[T](func(v T) bool {
+		for _, p := range predicates {
+			if p.Accept(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not returns a predicate that negates the condition defined by the passed predicate.
+func Not[T any](predicate Predicate[T]) Predicate[T] {
+	return PredicateFunc[T](func(v T) bool {
+		return !predicate.Accept(v)
+	})
+}
+
+// True returns a predicate that returns true for all inputs.
+func True[T any]() Predicate[T] {
+	return PredicateFunc[T](func(v T) bool { return true })
+}
+
+// False returns a predicate that returns false for all inputs.
+func False[T any]() Predicate[T] {
+	return PredicateFunc[T](func(v T) bool { return false })
+}
+
+// ExtractedValueAccepted returns A predicate that extracts a value using the Extractor and passes that value to the
+// provided predicate.  The output type of the extractor must match the input type of the predicate.
+func ExtractedValueAccepted[In, Out any](extractor Extractor[In, Out], predicate Predicate[Out]) Predicate[In] {
+	return PredicateFunc[In](func(v In) bool {
+		return predicate.Accept(extractor.Extract(v))
+	})
+}
+
+// MethodIs returns a predicate that takes a request, extracts the method, and returns true if it equals the method
+// provided, ignoring case.
+func MethodIs(method string) Predicate[*http.Request] {
+	return ExtractedValueAccepted(UpperCaseExtractor(ExtractMethod()), StringEquals(strings.ToUpper(method)))
+}
@@ -0,0 +1,33 @@
+package generic_test
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/generic"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+)
+
+func ExampleExtractedValueAccepted() {
+	req, _ := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	p := generic.ExtractedValueAccepted(generic.ExtractPath(), generic.StringStartsWith("/test/"))
+	fmt.Printf("%v\n", p.Accept(req))
+	// Output: true
+}
+
+func ExampleToPredicate() {
+	req, _ := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	p := predicate.And(generic.ToPredicate(generic.MethodIs("GET")), predicate.QueryParamEquals("q", "5"))
+	fmt.Printf("%v\n", p.Accept(req))
+	// Output: true
+}
@@ -0,0 +1,52 @@
+package generic_test
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	. "github.com/danapsimer/go-http-matchers/generic"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAnd(t *testing.T) {
+	assert.True(t, And(True[int](), True[int]()).Accept(1))
+	assert.False(t, And(True[int](), False[int]()).Accept(1))
+	assert.False(t, And(False[int](), True[int]()).Accept(1))
+}
+
+func TestOr(t *testing.T) {
+	assert.True(t, Or(False[int](), True[int]()).Accept(1))
+	assert.True(t, Or(True[int](), False[int]()).Accept(1))
+	assert.False(t, Or(False[int](), False[int]()).Accept(1))
+}
+
+func TestNot(t *testing.T) {
+	assert.False(t, Not(True[int]()).Accept(1))
+	assert.True(t, Not(False[int]()).Accept(1))
+}
+
+func TestExtractedValueAccepted(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("FOO", "FOOBAR")
+
+	assert.True(t, ExtractedValueAccepted(ExtractHeader("FOO"), StringEquals("FOOBAR")).Accept(req))
+	assert.False(t, ExtractedValueAccepted(ExtractHeader("FOO"), StringEquals("snafu")).Accept(req))
+}
+
+func TestMethodIs(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.True(t, MethodIs("get").Accept(req))
+	assert.False(t, MethodIs("POST").Accept(req))
+}
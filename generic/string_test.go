@@ -0,0 +1,32 @@
+package generic_test
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	. "github.com/danapsimer/go-http-matchers/generic"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestStringPredicates(t *testing.T) {
+	assert.True(t, StringEquals("foobar").Accept("foobar"))
+	assert.False(t, StringEquals("barfoo").Accept("foobar"))
+	assert.True(t, StringContains("oob").Accept("foobar"))
+	assert.False(t, StringContains("snafu").Accept("foobar"))
+	assert.True(t, StringStartsWith("foo").Accept("foobar"))
+	assert.False(t, StringStartsWith("bar").Accept("foobar"))
+	assert.True(t, StringEndsWith("bar").Accept("foobar"))
+	assert.False(t, StringEndsWith("foo").Accept("foobar"))
+	assert.True(t, StringMatches(regexp.MustCompile("fo{2}bar")).Accept("foobar"))
+	assert.False(t, StringMatches(regexp.MustCompile("barfo{2}")).Accept("foobar"))
+}
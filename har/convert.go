@@ -0,0 +1,255 @@
+package har
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/mocktransport"
+	"github.com/danapsimer/go-http-matchers/recorder"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// HTTPRequest converts the entry's request into the *http.Request a server would have received: RequestURI and Host
+// are set and the body, taken from the post data's text or, failing that, its params, is buffered.  RemoteAddr is left
+// empty as archives do not record the address of the client, only the one of the server.  HTTP/2 pseudo-headers, e.g.
+// ":authority", are dropped.
+func (e *Entry) HTTPRequest() (*http.Request, error) {
+	u, err := url.Parse(e.Request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("har: request url: %w", err)
+	}
+	var body io.Reader
+	if postData := e.Request.PostData; postData != nil {
+		text := postData.Text
+		if text == "" && len(postData.Params) > 0 {
+			form := url.Values{}
+			for _, param := range postData.Params {
+				form.Add(param.Name, param.Value)
+			}
+			text = form.Encode()
+		}
+		body = strings.NewReader(text)
+	}
+	r, err := http.NewRequest(e.Request.Method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("har: request: %w", err)
+	}
+	r.RequestURI = u.RequestURI()
+	if proto, major, minor, ok := parseHTTPVersion(e.Request.HTTPVersion); ok {
+		r.Proto, r.ProtoMajor, r.ProtoMinor = proto, major, minor
+	}
+	for _, header := range e.Request.Headers {
+		switch {
+		case strings.HasPrefix(header.Name, ":"):
+		case strings.EqualFold(header.Name, "Host"):
+			r.Host = header.Value
+		default:
+			r.Header.Add(header.Name, header.Value)
+		}
+	}
+	if r.Header.Get("Content-Type") == "" && e.Request.PostData != nil && e.Request.PostData.MimeType != "" {
+		r.Header.Set("Content-Type", e.Request.PostData.MimeType)
+	}
+	if _, err := extractor.RequestBody(r); err != nil {
+		return nil, fmt.Errorf("har: request body: %w", err)
+	}
+	return r, nil
+}
+
+// HTTPResponse converts the entry's response into an *http.Response whose Request is the entry's request, so that
+// predicate.RequestMatches can be evaluated against it.  The body is decoded if it is encoded in base64 and buffered.
+func (e *Entry) HTTPResponse() (*http.Response, error) {
+	r, err := e.HTTPRequest()
+	if err != nil {
+		return nil, err
+	}
+	body := []byte(e.Response.Content.Text)
+	if e.Response.Content.Encoding == "base64" {
+		if body, err = base64.StdEncoding.DecodeString(e.Response.Content.Text); err != nil {
+			return nil, fmt.Errorf("har: response body: %w", err)
+		}
+	}
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Response.Status, e.Response.StatusText),
+		StatusCode:    e.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+	if proto, major, minor, ok := parseHTTPVersion(e.Response.HTTPVersion); ok {
+		resp.Proto, resp.ProtoMajor, resp.ProtoMinor = proto, major, minor
+	}
+	for _, header := range e.Response.Headers {
+		if !strings.HasPrefix(header.Name, ":") {
+			resp.Header.Add(header.Name, header.Value)
+		}
+	}
+	if _, err := extractor.ResponseBody(resp); err != nil {
+		return nil, fmt.Errorf("har: response body: %w", err)
+	}
+	return resp, nil
+}
+
+// NewEntry converts a request and the response to it into an entry.  The bodies of both are buffered so that they can
+// still be read.  Response bodies that are not valid UTF-8 are stored encoded in base64.
+func NewEntry(r *http.Request, resp *http.Response, started time.Time, duration time.Duration) (*Entry, error) {
+	requestBody, err := extractor.RequestBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("har: request body: %w", err)
+	}
+	responseBody, err := extractor.ResponseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("har: response body: %w", err)
+	}
+	requestData, _ := requestBody.Bytes()
+	responseData, _ := responseBody.Bytes()
+	milliseconds := float64(duration) / float64(time.Millisecond)
+	entry := &Entry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds,
+		Request: Request{
+			Method:      r.Method,
+			URL:         requestURL(r),
+			HTTPVersion: protocol(r.Proto),
+			Cookies:     cookies(r.Cookies()),
+			Headers:     nameValues(r.Header),
+			QueryString: nameValues(r.URL.Query()),
+			HeadersSize: -1,
+			BodySize:    int64(len(requestData)),
+		},
+		Response: Response{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: protocol(resp.Proto),
+			Cookies:     cookies(resp.Cookies()),
+			Headers:     nameValues(resp.Header),
+			Content: Content{
+				Size:     int64(len(responseData)),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     string(responseData),
+			},
+			RedirectURL: resp.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    int64(len(responseData)),
+		},
+		Timings: Timings{Wait: milliseconds},
+	}
+	if r.Host != "" {
+		entry.Request.Headers = append([]NameValue{{Name: "Host", Value: r.Host}}, entry.Request.Headers...)
+	}
+	if len(requestData) > 0 {
+		entry.Request.PostData = &PostData{MimeType: r.Header.Get("Content-Type"), Text: string(requestData)}
+	}
+	if !utf8.Valid(responseData) {
+		entry.Response.Content.Text = base64.StdEncoding.EncodeToString(responseData)
+		entry.Response.Content.Encoding = "base64"
+	}
+	return entry, nil
+}
+
+// FromRecordings converts the exchanges recorded by a recorder.Proxy into entries.  Recordings without a request,
+// i.e. those loaded from definitions, are skipped.
+func FromRecordings(recordings []*recorder.Recording) ([]*Entry, error) {
+	entries := make([]*Entry, 0, len(recordings))
+	for _, recording := range recordings {
+		if recording.Request == nil {
+			continue
+		}
+		r := recording.Request.Clone(recording.Request.Context())
+		if recording.Request.GetBody != nil {
+			r.Body, _ = recording.Request.GetBody()
+		}
+		entry, err := NewEntry(r, mocktransport.NewResponse(r, recording.Response), recording.Time, 0)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// requestURL returns the absolute URL of the request.  The URL of a request received by a server is made absolute
+// using its Host.
+func requestURL(r *http.Request) string {
+	if r.URL.IsAbs() {
+		return r.URL.String()
+	}
+	u := *r.URL
+	u.Scheme, u.Host = "http", r.Host
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+// parseHTTPVersion parses the HTTP version of an entry.  Browsers record HTTP/2 as "HTTP/2" or "h2" and HTTP/3 as
+// "HTTP/3" or "h3", which are returned as "HTTP/2.0" and "HTTP/3.0" like net/http does.
+func parseHTTPVersion(version string) (string, int, int, bool) {
+	proto := strings.ToUpper(version)
+	switch proto {
+	case "H2", "HTTP/2":
+		proto = "HTTP/2.0"
+	case "H3", "HTTP/3":
+		proto = "HTTP/3.0"
+	}
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	return proto, major, minor, ok
+}
+
+func protocol(proto string) string {
+	if proto == "" {
+		return "HTTP/1.1"
+	}
+	return proto
+}
+
+// nameValues returns the headers or query parameters sorted by name.
+func nameValues(values map[string][]string) []NameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := []NameValue{}
+	for _, name := range names {
+		for _, value := range values[name] {
+			out = append(out, NameValue{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+func cookies(httpCookies []*http.Cookie) []Cookie {
+	out := []Cookie{}
+	for _, c := range httpCookies {
+		cookie := Cookie{Name: c.Name, Value: c.Value, Path: c.Path, Domain: c.Domain, HTTPOnly: c.HttpOnly,
+			Secure: c.Secure}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.Format(time.RFC3339)
+		}
+		out = append(out, cookie)
+	}
+	return out
+}
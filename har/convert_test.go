@@ -0,0 +1,122 @@
+package har_test
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/har"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/recorder"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntry_HTTPRequest(t *testing.T) {
+	entries := readSample(t).Log.Entries
+
+	req, err := entries[0].HTTPRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "GET", req.Method)
+	assert.Equal(t, "shop.example.com", req.Host)
+	assert.Equal(t, "/api/orders?status=open&page=2", req.RequestURI)
+	assert.Equal(t, "HTTP/2.0", req.Proto)
+	assert.Equal(t, 2, req.ProtoMajor)
+	assert.Equal(t, "application/json", req.Header.Get("Accept"))
+	assert.Empty(t, req.Header.Get(":authority"))
+	assert.Equal(t, "abc", extractor.ExtractCookie("session").Extract(req))
+	assert.Empty(t, req.RemoteAddr)
+
+	req, err = entries[1].HTTPRequest()
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"item": "book", "quantity": 2}`, string(data))
+	assert.Equal(t, "book", extractor.ExtractJSONPath("$.item").Extract(req))
+
+	req, err = entries[2].HTTPRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+	assert.NoError(t, req.ParseForm())
+	assert.Equal(t, "ann", req.PostFormValue("user"))
+
+	_, err = (&Entry{Request: Request{Method: "GET", URL: "http://[::1"}}).HTTPRequest()
+	assert.Error(t, err)
+}
+
+func TestEntry_HTTPResponse(t *testing.T) {
+	entries := readSample(t).Log.Entries
+
+	resp, err := entries[0].HTTPResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "200 OK", resp.Status)
+	assert.Equal(t, "/api/orders", resp.Request.URL.Path)
+	assert.Equal(t, "42", extractor.ExtractResponseJSONPath("$.orders[0].id").Extract(resp))
+
+	resp, err = entries[1].HTTPResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "5", resp.Header.Get("Retry-After"))
+	data, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "try again!", string(data))
+
+	entry := *entries[1]
+	entry.Response.Content.Text = "not base64!"
+	_, err = entry.HTTPResponse()
+	assert.Error(t, err)
+}
+
+func TestNewEntry(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/orders?b=2&a=1", strings.NewReader(`{"item": "book"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+	resp := &http.Response{
+		StatusCode: 201,
+		Proto:      "HTTP/1.1",
+		Header:     http.Header{"Content-Type": {"image/png"}, "Set-Cookie": {"session=abc; Path=/; HttpOnly"}},
+		Body:       io.NopCloser(strings.NewReader("\x89PNG\xff")),
+	}
+	started := time.Date(2023, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	entry, err := NewEntry(req, resp, started, 1500*time.Microsecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-10-17T09:00:00Z", entry.StartedDateTime)
+	assert.Equal(t, 1.5, entry.Time)
+	assert.Equal(t, "http://example.com/api/orders?b=2&a=1", entry.Request.URL)
+	assert.Equal(t, []NameValue{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}, entry.Request.QueryString)
+	assert.Equal(t, []NameValue{
+		{Name: "Host", Value: "example.com"},
+		{Name: "Content-Type", Value: "application/json"},
+		{Name: "X-Tenant", Value: "acme"},
+	}, entry.Request.Headers)
+	assert.Equal(t, &PostData{MimeType: "application/json", Text: `{"item": "book"}`}, entry.Request.PostData)
+	assert.Equal(t, "Created", entry.Response.StatusText)
+	assert.Equal(t, "base64", entry.Response.Content.Encoding)
+	assert.Equal(t, []Cookie{{Name: "session", Value: "abc", Path: "/", HTTPOnly: true}}, entry.Response.Cookies)
+
+	data, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"item": "book"}`, string(data), "the request body can still be read")
+	converted, err := entry.HTTPResponse()
+	assert.NoError(t, err)
+	data, _ = io.ReadAll(converted.Body)
+	assert.Equal(t, "\x89PNG\xff", string(data))
+	assert.Equal(t, "acme", converted.Request.Header.Get("X-Tenant"))
+}
+
+func TestFromRecordings(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/orders/42", strings.NewReader("paid"))
+	extractor.RequestBody(req)
+	recordings := []*recorder.Recording{
+		{Request: req, Response: mockserver.Response{Status: 204, Header: http.Header{"X-Id": {"42"}}}},
+		{Response: mockserver.Response{Body: "loaded from a definition"}},
+	}
+
+	entries, err := FromRecordings(recordings)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "PUT", entries[0].Request.Method)
+	assert.Equal(t, "paid", entries[0].Request.PostData.Text)
+	assert.Equal(t, 204, entries[0].Response.Status)
+	assert.Equal(t, []NameValue{{Name: "X-Id", Value: "42"}}, entries[0].Response.Headers)
+}
@@ -0,0 +1,76 @@
+package har
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/predicate"
+)
+
+// EntryResult is the outcome of evaluating a predicate against an entry.
+type EntryResult struct {
+	// Index is the position of the entry in the log.
+	Index int
+	Entry *Entry
+	// Accepted is true if the predicate accepted the entry.
+	Accepted bool
+	// Result explains why the predicate accepted or rejected the entry.  It is nil if Err is set.
+	Result *predicate.Result
+	// Err is set if the entry could not be converted, e.g. because its URL is invalid.
+	Err error
+}
+
+// Evaluate evaluates the predicate against the request of every entry.
+func (l *Log) Evaluate(p predicate.Predicate) []*EntryResult {
+	return l.evaluate(p, func(e *Entry) (interface{}, error) { return e.HTTPRequest() })
+}
+
+// EvaluateResponses evaluates the predicate against the response of every entry, e.g. predicate.And(
+// predicate.RequestMatches(predicate.PathStartsWith("/api/")), predicate.StatusIn("5xx")).
+func (l *Log) EvaluateResponses(p predicate.Predicate) []*EntryResult {
+	return l.evaluate(p, func(e *Entry) (interface{}, error) { return e.HTTPResponse() })
+}
+
+func (l *Log) evaluate(p predicate.Predicate, convert func(*Entry) (interface{}, error)) []*EntryResult {
+	results := make([]*EntryResult, 0, len(l.Entries))
+	for i, entry := range l.Entries {
+		result := &EntryResult{Index: i, Entry: entry}
+		if v, err := convert(entry); err != nil {
+			result.Err = err
+		} else {
+			result.Result = predicate.Evaluate(p, v)
+			result.Accepted = result.Result.Accepted
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Filter returns the entries whose requests the predicate accepts.  Entries that cannot be converted are skipped.
+func (l *Log) Filter(p predicate.Predicate) []*Entry {
+	return accepted(l.Evaluate(p))
+}
+
+// FilterResponses returns the entries whose responses the predicate accepts.  Entries that cannot be converted are
+// skipped.
+func (l *Log) FilterResponses(p predicate.Predicate) []*Entry {
+	return accepted(l.EvaluateResponses(p))
+}
+
+func accepted(results []*EntryResult) []*Entry {
+	var entries []*Entry
+	for _, result := range results {
+		if result.Accepted {
+			entries = append(entries, result.Entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,29 @@
+package har_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/har"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"os"
+)
+
+func ExampleLog_EvaluateResponses() {
+	file, err := os.Open("testdata/sample.har")
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	archive, err := har.Read(file)
+	if err != nil {
+		panic(err)
+	}
+
+	failures := predicate.And(predicate.RequestMatches(predicate.PathStartsWith("/api/")), predicate.StatusIn("5xx"))
+	for _, result := range archive.Log.EvaluateResponses(failures) {
+		if result.Accepted {
+			fmt.Println(result.Index, result.Entry.Request.Method, result.Entry.Request.URL, result.Entry.Response.Status)
+		}
+	}
+	// Output:
+	// 1 POST https://shop.example.com/api/orders 503
+}
@@ -0,0 +1,43 @@
+package har_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/har"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLog_Evaluate(t *testing.T) {
+	log := readSample(t).Log
+	log.Entries = append(log.Entries, &Entry{Request: Request{Method: "GET", URL: "http://[::1"}})
+
+	results := log.Evaluate(predicate.And(predicate.MethodIs("POST"), predicate.PathStartsWith("/api/")))
+	assert.Len(t, results, 4)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.True(t, result.Entry == log.Entries[i])
+	}
+	assert.False(t, results[0].Accepted)
+	assert.Contains(t, results[0].Result.Explanation(), "method")
+	assert.True(t, results[1].Accepted)
+	assert.False(t, results[2].Accepted)
+	assert.Error(t, results[3].Err)
+	assert.Nil(t, results[3].Result)
+
+	filtered := log.Filter(predicate.FormFieldEquals("user", "ann"))
+	assert.Equal(t, []*Entry{log.Entries[2]}, filtered)
+}
+
+func TestLog_EvaluateResponses(t *testing.T) {
+	log := readSample(t).Log
+	p := predicate.And(predicate.RequestMatches(predicate.PathStartsWith("/api/")), predicate.StatusIn("5xx"))
+
+	results := log.EvaluateResponses(p)
+	assert.Len(t, results, 3)
+	assert.False(t, results[0].Accepted)
+	assert.True(t, results[1].Accepted)
+	assert.False(t, results[2].Accepted)
+	assert.Equal(t, []*Entry{log.Entries[1]}, log.FilterResponses(p))
+	assert.Equal(t, []*Entry{log.Entries[0]},
+		log.FilterResponses(predicate.ResponseBodyJSONPathEquals("$.orders[0].id", "42")))
+}
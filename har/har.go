@@ -0,0 +1,199 @@
+// Package har reads and writes HTTP Archives (HAR 1.2), the format browser developer tools and proxies export captured
+// traffic in, and evaluates predicates against the entries of an archive:
+//
+//	archive, err := har.Read(file)
+//	results, err := archive.Log.Evaluate(predicate.And(predicate.MethodIs("POST"), predicate.PathStartsWith("/api/")))
+//	for _, result := range results {
+//		if result.Accepted {
+//			fmt.Println(result.Index, result.Entry.Request.URL)
+//		}
+//	}
+//
+// Entries are converted to *http.Request and *http.Response values, so any predicate can be evaluated against them.
+// Traffic can be archived with NewEntry, which converts a request and its response into an entry, or
+// FromRecordings, which converts the exchanges recorded by a recorder.Proxy.
+package har
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Version is the version of the HAR format written by this package.
+const Version = "1.2"
+
+// HAR is an HTTP Archive.
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log holds the entries of an archive.
+type Log struct {
+	Version string   `json:"version"`
+	Creator Creator  `json:"creator"`
+	Browser *Creator `json:"browser,omitempty"`
+	Pages   []Page   `json:"pages,omitempty"`
+	Entries []*Entry `json:"entries"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+// Creator names the application that created the archive, or the browser that made the requests.
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Page is a page the entries of an archive were requested by.
+type Page struct {
+	StartedDateTime string          `json:"startedDateTime"`
+	ID              string          `json:"id"`
+	Title           string          `json:"title"`
+	PageTimings     json.RawMessage `json:"pageTimings,omitempty"`
+	Comment         string          `json:"comment,omitempty"`
+}
+
+// Entry is an exchange: a request and the response to it.
+type Entry struct {
+	Pageref         string   `json:"pageref,omitempty"`
+	StartedDateTime string   `json:"startedDateTime"`
+	Time            float64  `json:"time"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+	Cache           Cache    `json:"cache"`
+	Timings         Timings  `json:"timings"`
+	ServerIPAddress string   `json:"serverIPAddress,omitempty"`
+	Connection      string   `json:"connection,omitempty"`
+	Comment         string   `json:"comment,omitempty"`
+}
+
+// Request is the request of an entry.
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []Cookie    `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+	HeadersSize int64       `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+	Comment     string      `json:"comment,omitempty"`
+}
+
+// Response is the response of an entry.
+type Response struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []Cookie    `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	Content     Content     `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int64       `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+	Comment     string      `json:"comment,omitempty"`
+}
+
+// NameValue is a header or a query parameter.
+type NameValue struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Cookie is a cookie sent with a request or set by a response.
+type Cookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// PostData is the body of a request.  Params holds the fields of a form when the body's text is not recorded.
+type PostData struct {
+	MimeType string  `json:"mimeType"`
+	Params   []Param `json:"params,omitempty"`
+	Text     string  `json:"text"`
+	Comment  string  `json:"comment,omitempty"`
+}
+
+// Param is a field of a form posted in the body of a request.
+type Param struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// Content is the body of a response.  If Encoding is "base64", Text holds the body encoded in base64.
+type Content struct {
+	Size        int64  `json:"size"`
+	Compression int64  `json:"compression,omitempty"`
+	MimeType    string `json:"mimeType"`
+	Text        string `json:"text,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// Cache holds information about the cache entries used by a request.  It is kept as is.
+type Cache struct {
+	BeforeRequest json.RawMessage `json:"beforeRequest,omitempty"`
+	AfterRequest  json.RawMessage `json:"afterRequest,omitempty"`
+	Comment       string          `json:"comment,omitempty"`
+}
+
+// Timings breaks down the time an entry took, in milliseconds.  -1 means that a timing does not apply.
+type Timings struct {
+	Blocked float64 `json:"blocked,omitempty"`
+	DNS     float64 `json:"dns,omitempty"`
+	Connect float64 `json:"connect,omitempty"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl,omitempty"`
+	Comment string  `json:"comment,omitempty"`
+}
+
+// New returns an empty archive created by the named application.
+func New(creator, version string) *HAR {
+	return &HAR{Log: Log{Version: Version, Creator: Creator{Name: creator, Version: version}, Entries: []*Entry{}}}
+}
+
+// Read decodes an archive.
+func Read(r io.Reader) (*HAR, error) {
+	archive := &HAR{}
+	if err := json.NewDecoder(r).Decode(archive); err != nil {
+		return nil, fmt.Errorf("har: %w", err)
+	}
+	return archive, nil
+}
+
+// Write encodes the archive as indented JSON.
+func (h *HAR) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(h)
+}
+
+// Add appends the entries to the archive.
+func (h *HAR) Add(entries ...*Entry) {
+	h.Log.Entries = append(h.Log.Entries, entries...)
+}
@@ -0,0 +1,51 @@
+package har_test
+
+import (
+	"bytes"
+	. "github.com/danapsimer/go-http-matchers/har"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func readSample(t *testing.T) *HAR {
+	file, err := os.Open("testdata/sample.har")
+	assert.NoError(t, err, "failed to open the sample archive.")
+	defer file.Close()
+	archive, err := Read(file)
+	assert.NoError(t, err, "failed to read the sample archive.")
+	return archive
+}
+
+func TestRead(t *testing.T) {
+	archive := readSample(t)
+	assert.Equal(t, "1.2", archive.Log.Version)
+	assert.Equal(t, "Firefox", archive.Log.Creator.Name)
+	assert.Len(t, archive.Log.Pages, 1)
+	assert.Len(t, archive.Log.Entries, 3)
+	assert.Equal(t, "page_1", archive.Log.Entries[0].Pageref)
+	assert.Equal(t, 42.5, archive.Log.Entries[0].Time)
+	assert.Equal(t, "base64", archive.Log.Entries[1].Response.Content.Encoding)
+
+	_, err := Read(strings.NewReader(`{"log": [`))
+	assert.Error(t, err)
+}
+
+func TestHAR_Write(t *testing.T) {
+	archive := readSample(t)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, archive.Write(buf))
+	written, err := Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, archive.Log.Creator, written.Log.Creator)
+	assert.JSONEq(t, string(archive.Log.Pages[0].PageTimings), string(written.Log.Pages[0].PageTimings))
+	assert.Equal(t, archive.Log.Entries, written.Log.Entries)
+
+	archive = New("go-http-matchers", "1.0")
+	archive.Add(&Entry{Request: Request{Method: "GET", URL: "http://example.com/"}})
+	buf.Reset()
+	assert.NoError(t, archive.Write(buf))
+	assert.Contains(t, buf.String(), `"version": "1.2"`)
+	assert.Contains(t, buf.String(), `"url": "http://example.com/"`)
+}
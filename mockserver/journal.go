@@ -0,0 +1,168 @@
+package mockserver
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Caller is implemented by the stubs a Journal keeps track of, e.g. *Stub or *mocktransport.Stub.  The nil stub is
+// the zero value of the type.
+type Caller interface {
+	comparable
+	// Calls returns the number of requests the stub has responded to.
+	Calls() int
+}
+
+// Recorded is a request recorded by a Journal.
+type Recorded[S Caller] struct {
+	// Request is a copy of the request.  Its body has been buffered and can be inspected by predicates.
+	Request *http.Request
+	// Stub is the stub that responded to the request, or the zero S, i.e. nil, if no stub accepted it.
+	Stub S
+	// Time is when the request was recorded.
+	Time time.Time
+}
+
+// Journal holds the stubs of a Server or of a mocktransport.Transport, along with their predicates, and records the
+// requests they are sent so that tests can verify them.  The zero Journal is empty and ready to use.  A Journal is
+// safe for concurrent use.
+type Journal[S Caller] struct {
+	mu         sync.Mutex
+	stubs      []S
+	predicates []predicate.Predicate
+	requests   []*Recorded[S]
+}
+
+// Add adds the stub, which responds to the requests accepted by the predicate.
+func (j *Journal[S]) Add(p predicate.Predicate, stub S) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stubs = append(j.stubs, stub)
+	j.predicates = append(j.predicates, p)
+}
+
+// Reset removes all of the stubs and forgets all of the recorded requests.
+func (j *Journal[S]) Reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stubs, j.predicates, j.requests = nil, nil, nil
+}
+
+// Stubs returns the stubs in the order they were added.
+func (j *Journal[S]) Stubs() []S {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]S(nil), j.stubs...)
+}
+
+// Record records a copy of the request, whose body must have been buffered, and the stub that responded to it, nil if
+// none did.
+func (j *Journal[S]) Record(r *http.Request, stub S) {
+	recorded := &Recorded[S]{Request: r.Clone(context.Background()), Stub: stub, Time: time.Now()}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.requests = append(j.requests, recorded)
+}
+
+// Requests returns the recorded requests in the order they were recorded.
+func (j *Journal[S]) Requests() []*Recorded[S] {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]*Recorded[S](nil), j.requests...)
+}
+
+// UnmatchedRequests returns the requests that no stub accepted.
+func (j *Journal[S]) UnmatchedRequests() []*Recorded[S] {
+	var unmatched []*Recorded[S]
+	var none S
+	for _, recorded := range j.Requests() {
+		if recorded.Stub == none {
+			unmatched = append(unmatched, recorded)
+		}
+	}
+	return unmatched
+}
+
+// CallCount returns the number of recorded requests that the predicate accepts.
+func (j *Journal[S]) CallCount(p predicate.Predicate) int {
+	count := 0
+	for _, recorded := range j.Requests() {
+		if p.Accept(recorded.Request) {
+			count++
+		}
+	}
+	return count
+}
+
+// Verify returns an error unless the predicate accepts exactly 'times' of the recorded requests.  The error lists the
+// recorded requests and why the predicate rejected them.
+func (j *Journal[S]) Verify(p predicate.Predicate, times int) error {
+	count := j.CallCount(p)
+	if count == times {
+		return nil
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "expected %d requests accepted by %v, got %d", times, p, count)
+	for i, recorded := range j.Requests() {
+		accepted, explanation := predicate.Explain(p, recorded.Request)
+		fmt.Fprintf(sb, "\nrequest %d: %s %s", i+1, recorded.Request.Method, recorded.Request.URL)
+		if accepted {
+			sb.WriteString("\n  accepted")
+			continue
+		}
+		for _, line := range strings.Split(explanation, "\n") {
+			sb.WriteString("\n  " + line)
+		}
+	}
+	return fmt.Errorf("%s", sb.String())
+}
+
+// VerifyAllStubsCalled returns an error listing the predicates of the stubs that have not responded to any request.
+func (j *Journal[S]) VerifyAllStubsCalled() error {
+	j.mu.Lock()
+	stubs, predicates := j.stubs, j.predicates
+	j.mu.Unlock()
+	var uncalled []string
+	for i, stub := range stubs {
+		if stub.Calls() == 0 {
+			uncalled = append(uncalled, fmt.Sprint(predicates[i]))
+		}
+	}
+	if len(uncalled) == 0 {
+		return nil
+	}
+	return fmt.Errorf("stubs not called:\n  %s", strings.Join(uncalled, "\n  "))
+}
+
+// Unmatched describes a request that no stub accepted: its method and URL followed, if there are stubs, by the
+// predicate of the stub that came closest to accepting it and why it rejected the request.  See predicate.Closest.
+func (j *Journal[S]) Unmatched(r *http.Request) string {
+	j.mu.Lock()
+	predicates := j.predicates
+	j.mu.Unlock()
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%s %s", r.Method, r.URL)
+	if i, result := predicate.Closest(predicates, r); i >= 0 {
+		fmt.Fprintf(sb, "\nclosest stub: %v", predicates[i])
+		for _, line := range strings.Split(result.Explanation(), "\n") {
+			sb.WriteString("\n  " + line)
+		}
+	}
+	return sb.String()
+}
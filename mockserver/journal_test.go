@@ -0,0 +1,42 @@
+package mockserver_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	journal := &Journal[*Stub]{}
+	orders := &Stub{Predicate: predicate.PathEquals("/orders")}
+	users := &Stub{Predicate: predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/users"))}
+	journal.Add(orders.Predicate, orders)
+	journal.Add(users.Predicate, users)
+	assert.Equal(t, []*Stub{orders, users}, journal.Stubs())
+
+	r := httptest.NewRequest("POST", "/users", nil)
+	assert.Equal(t, "POST /users\nclosest stub: And(MethodIs(\"GET\"), PathEquals(\"/users\"))\n  upper case "+
+		"method: expected 'GET', got 'POST'", journal.Unmatched(r))
+	journal.Record(r, nil)
+	orders.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/orders", nil))
+	journal.Record(httptest.NewRequest("GET", "/orders", nil), orders)
+
+	requests := journal.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Nil(t, requests[0].Stub)
+		assert.Equal(t, orders, requests[1].Stub)
+	}
+	assert.Equal(t, requests[:1], journal.UnmatchedRequests())
+	assert.Equal(t, 1, journal.CallCount(predicate.MethodIs("GET")))
+	assert.NoError(t, journal.Verify(predicate.PathEquals("/users"), 1))
+	assert.EqualError(t, journal.VerifyAllStubsCalled(),
+		"stubs not called:\n  And(MethodIs(\"GET\"), PathEquals(\"/users\"))")
+
+	journal.Reset()
+	assert.Empty(t, journal.Stubs())
+	assert.Empty(t, journal.Requests())
+	assert.NoError(t, journal.VerifyAllStubsCalled())
+	assert.NoError(t, journal.Verify(predicate.True(), 0))
+}
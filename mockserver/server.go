@@ -0,0 +1,199 @@
+// Package mockserver provides an HTTP server for tests whose responses are stubbed using predicates.  Every request
+// the server receives is recorded so that tests can verify how the code under test called it:
+//
+//	server := mockserver.NewServer()
+//	defer server.Close()
+//	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")),
+//		mockserver.Response{Status: 200, Body: `{"id": "42"}`})
+//
+//	// ... exercise the code under test against server.URL ...
+//
+//	if err := server.Verify(predicate.PathEquals("/orders/42"), 1); err != nil {
+//		t.Error(err)
+//	}
+//
+// Requests that no stub accepts are answered with a 404 whose body reports the stub that came closest to accepting
+// the request and why it rejected it.  Requests whose body is larger than extractor.DefaultMaxBodySize are answered
+// with a 413 and requests whose body can not be read with a 400.  Neither is recorded.
+package mockserver
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/mux"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// Response defines the response written for the requests accepted by a Stub.  It can be marshaled to and from JSON
+// and YAML along with the predicate of the stub, see the recorder package.
+type Response struct {
+	// Status is the status code of the response.  If 0, 200 is used.
+	Status int `json:"status,omitempty" yaml:"status,omitempty"`
+	// Header holds the headers of the response.
+	Header http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	// Body is the body of the response.
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+	// Delay is how long to wait before writing the response.
+	Delay time.Duration `json:"delay,omitempty" yaml:"delay,omitempty"`
+}
+
+// Stub is a predicate and the response written for the requests it accepts.
+type Stub struct {
+	Predicate predicate.Predicate
+	Response  Response
+
+	mu    sync.Mutex
+	calls int
+}
+
+// Calls returns the number of requests the stub has responded to.
+func (s *Stub) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// ServeHTTP writes the stub's response.
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	s.Response.ServeHTTP(w, r)
+}
+
+// ServeHTTP writes the response once Delay has elapsed.  Nothing is written if the request is canceled first.
+func (response Response) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	for name, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(response.Body))
+}
+
+// RecordedRequest is a request received by the server.
+type RecordedRequest = Recorded[*Stub]
+
+// Server is an httptest.Server that responds to requests using stubs and records the requests it receives.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	mux     *mux.Mux
+	journal Journal[*Stub]
+}
+
+// NewServer starts and returns a new Server with no stubs.  The caller should call Close when finished, to shut it
+// down.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a new Server that has not been started, so that its configuration can be changed before
+// calling Start or StartTLS.
+func NewUnstartedServer() *Server {
+	s := &Server{}
+	s.Reset()
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Stub registers a stub that writes the response for the requests accepted by the predicate.  Stubs are tried in the
+// order they were registered.
+func (s *Server) Stub(p predicate.Predicate, response Response) *Stub {
+	stub := &Stub{Predicate: p, Response: response}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.journal.Add(p, stub)
+	s.mux.Handle(p, stub)
+	return stub
+}
+
+// Reset removes all of the stubs and forgets all of the recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mux = mux.NewMux()
+	s.journal.Reset()
+}
+
+// Stubs returns the registered stubs.
+func (s *Server) Stubs() []*Stub {
+	return s.journal.Stubs()
+}
+
+// Requests returns the requests received so far in the order they were received.
+func (s *Server) Requests() []*RecordedRequest {
+	return s.journal.Requests()
+}
+
+// UnmatchedRequests returns the requests that no stub accepted.
+func (s *Server) UnmatchedRequests() []*RecordedRequest {
+	return s.journal.UnmatchedRequests()
+}
+
+// CallCount returns the number of recorded requests that the predicate accepts.
+func (s *Server) CallCount(p predicate.Predicate) int {
+	return s.journal.CallCount(p)
+}
+
+// Verify returns an error unless the predicate accepts exactly 'times' of the recorded requests.  See Journal.Verify.
+func (s *Server) Verify(p predicate.Predicate, times int) error {
+	return s.journal.Verify(p, times)
+}
+
+// VerifyAllStubsCalled returns an error listing the stubs that have not responded to any request.
+func (s *Server) VerifyAllStubsCalled() error {
+	return s.journal.VerifyAllStubsCalled()
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if _, err := extractor.RequestBody(r); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, extractor.ErrBodyTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, "request body can not be read: "+err.Error(), status)
+		return
+	}
+	s.mu.Lock()
+	m := s.mux
+	s.mu.Unlock()
+	route := m.Match(r)
+	if route == nil {
+		s.journal.Record(r, nil)
+		http.Error(w, "no stub matched "+s.journal.Unmatched(r), http.StatusNotFound)
+		return
+	}
+	s.journal.Record(r, route.Handler.(*Stub))
+	route.ServeHTTP(w, r)
+}
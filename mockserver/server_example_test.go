@@ -0,0 +1,26 @@
+package mockserver_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"io"
+	"net/http"
+)
+
+func ExampleServer() {
+	server := mockserver.NewServer()
+	defer server.Close()
+	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")),
+		mockserver.Response{Body: `{"id": "42"}`})
+
+	resp, _ := http.Get(server.URL + "/orders/42")
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	fmt.Println(resp.StatusCode, string(body))
+
+	fmt.Println(server.Verify(predicate.PathEquals("/orders/42"), 1))
+	// Output:
+	// 200 {"id": "42"}
+	// <nil>
+}
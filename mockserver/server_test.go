@@ -0,0 +1,133 @@
+package mockserver_test
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func do(t *testing.T, method, url, body string) (*http.Response, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data)
+}
+
+func TestServer_Stub(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")), Response{
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   `{"id": "42"}`,
+	})
+	server.Stub(predicate.And(predicate.MethodIs("POST"), predicate.PathEquals("/orders")),
+		Response{Status: http.StatusCreated})
+
+	resp, body := do(t, "GET", server.URL+"/orders/42", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"id": "42"}`, body)
+
+	resp, _ = do(t, "POST", server.URL+"/orders", `{"item": "widget"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestServer_Delay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Stub(predicate.True(), Response{Delay: 50 * time.Millisecond})
+
+	start := time.Now()
+	do(t, "GET", server.URL+"/slow", "")
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestServer_Verify(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	stub := server.Stub(predicate.PathEquals("/orders"), Response{})
+	uncalled := server.Stub(predicate.PathEquals("/users"), Response{})
+
+	do(t, "POST", server.URL+"/orders", `{"item": "widget"}`)
+	do(t, "POST", server.URL+"/orders", `{"item": "gadget"}`)
+	do(t, "GET", server.URL+"/other", "")
+
+	assert.Equal(t, 2, stub.Calls())
+	assert.Equal(t, 0, uncalled.Calls())
+	requests := server.Requests()
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, stub, requests[0].Stub)
+		assert.Nil(t, requests[2].Stub)
+	}
+	assert.Len(t, server.UnmatchedRequests(), 1)
+
+	widget := predicate.And(predicate.PathEquals("/orders"), predicate.BodyJSONPathEquals("$.item", "widget"))
+	assert.Equal(t, 1, server.CallCount(widget))
+	assert.NoError(t, server.Verify(widget, 1))
+	err := server.Verify(widget, 2)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected 2 requests")
+		assert.Contains(t, err.Error(), "request 3: GET /other")
+	}
+
+	err = server.VerifyAllStubsCalled()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `PathEquals("/users")`)
+		assert.NotContains(t, err.Error(), `PathEquals("/orders")`)
+	}
+
+	server.Reset()
+	assert.Empty(t, server.Requests())
+	assert.Empty(t, server.Stubs())
+}
+
+func TestServer_Unmatched(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/users")), Response{})
+	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders"),
+		predicate.HeaderEquals("Accept", "application/json")), Response{})
+
+	resp, body := do(t, "GET", server.URL+"/orders", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "no stub matched GET /orders")
+	assert.Contains(t, body, `closest stub: And(MethodIs("GET"), PathEquals("/orders")`)
+	assert.Contains(t, body, "Accept")
+}
+
+func TestServer_UnmatchedMethod(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/users")), Response{})
+
+	resp, body := do(t, "POST", server.URL+"/users", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Allow"))
+	assert.Contains(t, body, "no stub matched POST /users")
+	assert.Len(t, server.UnmatchedRequests(), 1)
+}
+
+func TestServer_OversizedRequest(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Stub(predicate.PathEquals("/upload"), Response{Body: "ok"})
+
+	resp, body := do(t, "POST", server.URL+"/upload", strings.Repeat("x", int(extractor.DefaultMaxBodySize)+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Contains(t, body, extractor.ErrBodyTooLarge.Error())
+	assert.Empty(t, server.Requests())
+	assert.Equal(t, 0, server.Stubs()[0].Calls())
+}
@@ -0,0 +1,213 @@
+// Package mocktransport provides an http.RoundTripper for tests of HTTP clients whose responses are stubbed using
+// predicates, so that the client can be tested without starting a server:
+//
+//	transport := mocktransport.NewTransport()
+//	transport.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")),
+//		mockserver.Response{Status: 500}, mockserver.Response{Body: `{"id": "42"}`})
+//	client := &http.Client{Transport: transport}
+//
+//	// ... exercise the code under test with client ...
+//
+//	if err := transport.VerifyAllStubsCalled(); err != nil {
+//		t.Error(err)
+//	}
+//
+// Requests that no stub accepts are sent to the Fallback transport if there is one.  Otherwise RoundTrip returns an
+// error wrapping ErrUnmatched that reports the stub that came closest to accepting the request and why it rejected it.
+package mocktransport
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnmatched is returned by RoundTrip when no stub accepts the request and the transport has no Fallback.
+var ErrUnmatched = errors.New("no stub matched the request")
+
+// ResponseFunc returns the response to a request accepted by a Stub.  The request's body has been buffered and can be
+// read again.  An error is returned by RoundTrip as is, e.g. to simulate a network failure.
+type ResponseFunc func(*http.Request) (*http.Response, error)
+
+// Stub is a predicate and the responses returned for the requests it accepts.
+type Stub struct {
+	Predicate predicate.Predicate
+	// Responses are returned in turn, one per call, and the last one is repeated once all of them have been returned.
+	// They are ignored if Func is set.
+	Responses []mockserver.Response
+	// Func, if set, builds the response to every call.
+	Func ResponseFunc
+
+	mu    sync.Mutex
+	calls int
+}
+
+// Calls returns the number of requests the stub has responded to.
+func (s *Stub) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// respond returns the stub's response to the request.
+func (s *Stub) respond(r *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	call := s.calls
+	s.calls++
+	s.mu.Unlock()
+	if s.Func != nil {
+		return s.Func(r)
+	}
+	response := mockserver.Response{}
+	if len(s.Responses) > 0 {
+		response = s.Responses[len(s.Responses)-1]
+		if call < len(s.Responses) {
+			response = s.Responses[call]
+		}
+	}
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+	}
+	return NewResponse(r, response), nil
+}
+
+// NewResponse builds the *http.Response a server writing the response would return to the request.
+func NewResponse(r *http.Request, response mockserver.Response) *http.Response {
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       r,
+	}
+}
+
+// RecordedRequest is a request sent through the transport.
+type RecordedRequest = mockserver.Recorded[*Stub]
+
+// Transport is an http.RoundTripper that responds to requests using stubs and records the requests it is sent.
+type Transport struct {
+	// Fallback, if set, sends the requests that no stub accepts, e.g. http.DefaultTransport.
+	Fallback http.RoundTripper
+
+	journal mockserver.Journal[*Stub]
+}
+
+// NewTransport returns a new Transport with no stubs and no Fallback.
+func NewTransport() *Transport {
+	return &Transport{}
+}
+
+// Stub registers a stub that returns the responses, in turn, for the requests accepted by the predicate, e.g.
+// Stub(p, Response{Status: 500}, Response{Status: 200}) fails the first call and succeeds on every call after it.
+// Without responses, the stub returns an empty 200.  Stubs are tried in the order they were registered.
+func (t *Transport) Stub(p predicate.Predicate, responses ...mockserver.Response) *Stub {
+	stub := &Stub{Predicate: p, Responses: responses}
+	t.journal.Add(p, stub)
+	return stub
+}
+
+// StubFunc registers a stub that calls f to respond to the requests accepted by the predicate.
+func (t *Transport) StubFunc(p predicate.Predicate, f ResponseFunc) *Stub {
+	stub := &Stub{Predicate: p, Func: f}
+	t.journal.Add(p, stub)
+	return stub
+}
+
+// Reset removes all of the stubs and forgets all of the recorded requests.
+func (t *Transport) Reset() {
+	t.journal.Reset()
+}
+
+// Stubs returns the registered stubs.
+func (t *Transport) Stubs() []*Stub {
+	return t.journal.Stubs()
+}
+
+// Requests returns the requests sent so far in the order they were sent.
+func (t *Transport) Requests() []*RecordedRequest {
+	return t.journal.Requests()
+}
+
+// UnmatchedRequests returns the requests that no stub accepted.
+func (t *Transport) UnmatchedRequests() []*RecordedRequest {
+	return t.journal.UnmatchedRequests()
+}
+
+// RoundTrip responds to the request with the first stub that accepts it.  The request is not modified: a copy of it,
+// whose body is buffered so that the predicates, the stub and the Fallback can all read it, is evaluated, recorded
+// and passed on.  The request's body is closed once it has been read, as http.RoundTripper requires.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+	req := r.Clone(r.Context())
+	if _, err := extractor.RequestBody(req); err != nil {
+		return nil, err
+	}
+	var matched *Stub
+	for _, stub := range t.Stubs() {
+		if stub.Predicate.Accept(req) {
+			matched = stub
+			break
+		}
+	}
+	t.journal.Record(req, matched)
+	switch {
+	case matched != nil:
+		return matched.respond(req)
+	case t.Fallback != nil:
+		return t.Fallback.RoundTrip(req)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnmatched, t.journal.Unmatched(req))
+}
+
+// CallCount returns the number of recorded requests that the predicate accepts.
+func (t *Transport) CallCount(p predicate.Predicate) int {
+	return t.journal.CallCount(p)
+}
+
+// Verify returns an error unless the predicate accepts exactly 'times' of the recorded requests.  See
+// mockserver.Journal.Verify.
+func (t *Transport) Verify(p predicate.Predicate, times int) error {
+	return t.journal.Verify(p, times)
+}
+
+// VerifyAllStubsCalled returns an error listing the stubs that have not responded to any request.
+func (t *Transport) VerifyAllStubsCalled() error {
+	return t.journal.VerifyAllStubsCalled()
+}
@@ -0,0 +1,30 @@
+package mocktransport_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/mocktransport"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+)
+
+func ExampleTransport() {
+	transport := mocktransport.NewTransport()
+	transport.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")),
+		mockserver.Response{Status: 503}, mockserver.Response{Body: `{"id": "42"}`})
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, _ := client.Get("http://api.example.com/orders/42")
+		resp.Body.Close()
+		fmt.Println(resp.StatusCode)
+	}
+	_, err := client.Get("http://api.example.com/users")
+	fmt.Println(err != nil)
+	fmt.Println(transport.VerifyAllStubsCalled())
+	// Output:
+	// 503
+	// 200
+	// true
+	// <nil>
+}
@@ -0,0 +1,200 @@
+package mocktransport_test
+
+import (
+	"context"
+	"errors"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	. "github.com/danapsimer/go-http-matchers/mocktransport"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func do(t *testing.T, client *http.Client, method, url, body string) (*http.Response, string, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data), nil
+}
+
+func TestTransport_Stub(t *testing.T) {
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+	transport.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders/42")), mockserver.Response{
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   `{"id": "42"}`,
+	})
+	transport.Stub(predicate.And(predicate.MethodIs("POST"), predicate.BodyJSONPathEquals("$.item", "widget")),
+		mockserver.Response{Status: http.StatusCreated})
+
+	resp, body, err := do(t, client, "GET", "http://api.example.com/orders/42", "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "200 OK", resp.Status)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, int64(12), resp.ContentLength)
+		assert.Equal(t, `{"id": "42"}`, body)
+	}
+
+	resp, _, err = do(t, client, "POST", "http://api.example.com/orders", `{"item": "widget"}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+}
+
+// closeRecorder is a request body that records whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (cr *closeRecorder) Close() error {
+	cr.closed = true
+	return nil
+}
+
+func TestTransport_RoundTripLeavesRequestUntouched(t *testing.T) {
+	transport := NewTransport()
+	transport.Stub(predicate.BodyJSONPathEquals("$.item", "widget"))
+
+	for _, item := range []string{"widget", "gadget"} {
+		matched := item == "widget"
+		body := &closeRecorder{Reader: strings.NewReader(`{"item": "` + item + `"}`)}
+		req, err := http.NewRequest("POST", "http://example.com/orders", body)
+		assert.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		assert.Equal(t, matched, err == nil, "%v", err)
+		assert.True(t, body.closed, item)
+		assert.True(t, req.Body == io.ReadCloser(body), item)
+		assert.Nil(t, req.GetBody, item)
+	}
+	requests := transport.Requests()
+	if assert.Len(t, requests, 2) {
+		data, _ := io.ReadAll(requests[1].Request.Body)
+		assert.Equal(t, `{"item": "gadget"}`, string(data))
+	}
+}
+
+func TestTransport_Sequence(t *testing.T) {
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+	stub := transport.Stub(predicate.PathEquals("/flaky"),
+		mockserver.Response{Status: 500}, mockserver.Response{Status: 503}, mockserver.Response{Status: 200})
+
+	var statuses []int
+	for i := 0; i < 4; i++ {
+		resp, _, err := do(t, client, "GET", "http://example.com/flaky", "")
+		if assert.NoError(t, err) {
+			statuses = append(statuses, resp.StatusCode)
+		}
+	}
+	assert.Equal(t, []int{500, 503, 200, 200}, statuses)
+	assert.Equal(t, 4, stub.Calls())
+}
+
+func TestTransport_StubFunc(t *testing.T) {
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+	transport.StubFunc(predicate.PathStartsWith("/echo"), func(r *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(r.Body)
+		return NewResponse(r, mockserver.Response{Body: r.URL.Path + ":" + string(data)}), nil
+	})
+	failure := errors.New("connection reset")
+	transport.StubFunc(predicate.PathEquals("/down"), func(r *http.Request) (*http.Response, error) {
+		return nil, failure
+	})
+
+	_, body, err := do(t, client, "PUT", "http://example.com/echo/a", "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "/echo/a:hello", body)
+
+	_, _, err = do(t, client, "GET", "http://example.com/down", "")
+	assert.True(t, errors.Is(err, failure), "%v", err)
+}
+
+func TestTransport_Delay(t *testing.T) {
+	transport := NewTransport()
+	transport.Stub(predicate.True(), mockserver.Response{Delay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest("GET", "http://example.com/slow", nil).WithContext(ctx)
+	_, err := transport.RoundTrip(req)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+}
+
+func TestTransport_Unmatched(t *testing.T) {
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+	transport.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/users")))
+	transport.Stub(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/orders"),
+		predicate.HeaderEquals("Accept", "application/json")))
+
+	_, _, err := do(t, client, "GET", "http://example.com/orders", "")
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrUnmatched), "%v", err)
+		assert.Contains(t, err.Error(), "GET http://example.com/orders")
+		assert.Contains(t, err.Error(), `closest stub: And(MethodIs("GET"), PathEquals("/orders")`)
+		assert.Contains(t, err.Error(), "Accept")
+	}
+	assert.Len(t, transport.UnmatchedRequests(), 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		w.Write([]byte("real " + string(data)))
+	}))
+	defer server.Close()
+	transport.Fallback = http.DefaultTransport
+	_, body, err := do(t, client, "POST", server.URL+"/orders", "order")
+	assert.NoError(t, err)
+	assert.Equal(t, "real order", body)
+}
+
+func TestTransport_Verify(t *testing.T) {
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+	stub := transport.Stub(predicate.PathEquals("/orders"))
+	uncalled := transport.Stub(predicate.PathEquals("/users"))
+
+	do(t, client, "POST", "http://example.com/orders", `{"item": "widget"}`)
+	do(t, client, "POST", "http://example.com/orders", `{"item": "gadget"}`)
+	do(t, client, "GET", "http://example.com/other", "")
+
+	assert.Equal(t, 2, stub.Calls())
+	assert.Equal(t, 0, uncalled.Calls())
+	requests := transport.Requests()
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, stub, requests[0].Stub)
+		assert.Nil(t, requests[2].Stub)
+	}
+
+	widget := predicate.And(predicate.PathEquals("/orders"), predicate.BodyJSONPathEquals("$.item", "widget"))
+	assert.Equal(t, 1, transport.CallCount(widget))
+	assert.NoError(t, transport.Verify(widget, 1))
+	err := transport.Verify(widget, 2)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected 2 requests")
+		assert.Contains(t, err.Error(), "request 3: GET http://example.com/other")
+	}
+
+	err = transport.VerifyAllStubsCalled()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `PathEquals("/users")`)
+		assert.NotContains(t, err.Error(), `PathEquals("/orders")`)
+	}
+
+	transport.Reset()
+	assert.Empty(t, transport.Requests())
+	assert.Empty(t, transport.Stubs())
+}
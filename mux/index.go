@@ -0,0 +1,348 @@
+package mux
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Index finds the first of a list of predicates that accepts a request without testing every predicate in turn.
+//
+// NewIndex looks for the constraints that requests must satisfy for each predicate to accept them: MethodIs,
+// HostnameEquals, HostnameIn without wildcards, PathEquals and PathStartsWith, either alone or as operands of an And,
+// possibly nested, and, for methods and host names, combined by Or.  The predicates are indexed by method, then by
+// host name, then by path in a radix tree, and only what remains of a predicate once its indexed constraints are
+// removed is evaluated, and only against the requests that satisfy them.  Predicates without indexable constraints
+// are evaluated against every request.  Constraints whose error policy is not RejectOnError are not indexed.
+//
+// Match returns exactly what testing the predicates in order and stopping at the first one that accepts the request
+// would.
+type Index struct {
+	predicates []predicate.Predicate
+	// residuals holds, for each predicate, what remains of it once its indexed constraints are removed.
+	residuals []predicate.Predicate
+	methods   map[string]*hostIndex
+	anyMethod *hostIndex
+	// byHost is true if a predicate is indexed by host name.
+	byHost bool
+}
+
+type hostIndex struct {
+	hosts   map[string]*radixNode
+	anyHost *radixNode
+}
+
+// constraints are the indexable constraints of a predicate.  A nil slice means that the predicate does not constrain
+// the corresponding part of the request.  A predicate that does not constrain the path is indexed under the prefix "".
+type constraints struct {
+	methods []string
+	hosts   []string
+	path    string
+	exact   bool
+	hasPath bool
+}
+
+// NewIndex indexes the predicates.  The predicates must not be modified afterwards.
+func NewIndex(predicates ...predicate.Predicate) *Index {
+	idx := &Index{
+		predicates: predicates,
+		residuals:  make([]predicate.Predicate, len(predicates)),
+		methods:    map[string]*hostIndex{},
+		anyMethod:  newHostIndex(),
+	}
+	for i, p := range predicates {
+		c, residual := analyze(p)
+		idx.residuals[i] = residual
+		idx.byHost = idx.byHost || c.hosts != nil
+		for _, hosts := range idx.hostIndexes(c.methods) {
+			for _, paths := range hosts.radixTrees(c.hosts) {
+				paths.insert(c.path, i, c.exact)
+			}
+		}
+	}
+	return idx
+}
+
+func newHostIndex() *hostIndex {
+	return &hostIndex{hosts: map[string]*radixNode{}, anyHost: &radixNode{}}
+}
+
+// hostIndexes returns the host indexes of the methods, creating them if needed.
+func (idx *Index) hostIndexes(methods []string) []*hostIndex {
+	if methods == nil {
+		return []*hostIndex{idx.anyMethod}
+	}
+	indexes := make([]*hostIndex, 0, len(methods))
+	for _, method := range methods {
+		if _, ok := idx.methods[method]; !ok {
+			idx.methods[method] = newHostIndex()
+		}
+		indexes = append(indexes, idx.methods[method])
+	}
+	return indexes
+}
+
+// radixTrees returns the path indexes of the hosts, creating them if needed.
+func (hi *hostIndex) radixTrees(hosts []string) []*radixNode {
+	if hosts == nil {
+		return []*radixNode{hi.anyHost}
+	}
+	trees := make([]*radixNode, 0, len(hosts))
+	for _, host := range hosts {
+		if _, ok := hi.hosts[host]; !ok {
+			hi.hosts[host] = &radixNode{}
+		}
+		trees = append(trees, hi.hosts[host])
+	}
+	return trees
+}
+
+// Len returns the number of indexed predicates.
+func (idx *Index) Len() int {
+	return len(idx.predicates)
+}
+
+// Match returns the position of the first predicate that accepts the request, or -1 if none does.
+func (idx *Index) Match(r *http.Request) int {
+	for _, i := range idx.candidates(r) {
+		if idx.residuals[i].Accept(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// candidates returns, in ascending order, the positions of the predicates whose indexed constraints the request
+// satisfies.
+func (idx *Index) candidates(r *http.Request) []int {
+	var candidates []int
+	hostIndexes := []*hostIndex{idx.anyMethod}
+	if hi, ok := idx.methods[strings.ToUpper(r.Method)]; ok {
+		hostIndexes = append(hostIndexes, hi)
+	}
+	hostname := ""
+	if idx.byHost {
+		if value, err := extractor.ExtractE(extractor.ExtractHostname(), r); err == nil {
+			hostname = value.(string)
+		}
+	}
+	for _, hi := range hostIndexes {
+		trees := []*radixNode{hi.anyHost}
+		if tree, ok := hi.hosts[hostname]; ok && hostname != "" {
+			trees = append(trees, tree)
+		}
+		for _, tree := range trees {
+			candidates = tree.lookup(r.URL.Path, candidates)
+		}
+	}
+	sort.Ints(candidates)
+	return candidates
+}
+
+// analyze returns the indexable constraints of the predicate and what remains of it once they are removed.
+func analyze(p predicate.Predicate) (constraints, predicate.Predicate) {
+	c := constraints{}
+	var rest []predicate.Predicate
+	for _, operand := range flattenAnd(p) {
+		if c.methods == nil && indexable(operand) {
+			if methods := methodsOf(operand); methods != nil {
+				c.methods = unique(methods)
+				continue
+			}
+		}
+		if c.hosts == nil && indexable(operand) {
+			if hosts := hostsOf(operand); hosts != nil {
+				c.hosts = unique(hosts)
+				continue
+			}
+		}
+		if !c.hasPath && indexable(operand) {
+			if path, exact, ok := pathOf(operand); ok {
+				c.path, c.exact, c.hasPath = path, exact, true
+				continue
+			}
+		}
+		rest = append(rest, operand)
+	}
+	switch len(rest) {
+	case 0:
+		return c, predicate.True()
+	case 1:
+		return c, rest[0]
+	}
+	return c, predicate.And(rest...)
+}
+
+// unique returns the values without duplicates so that a predicate is indexed only once under each of them.
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// flattenAnd returns the operands of the predicate and of the Ands nested in it, or the predicate itself if it is not
+// an And.
+func flattenAnd(p predicate.Predicate) []predicate.Predicate {
+	and, ok := p.(predicate.AndPredicate)
+	if !ok {
+		return []predicate.Predicate{p}
+	}
+	var operands []predicate.Predicate
+	for _, operand := range and {
+		operands = append(operands, flattenAnd(operand)...)
+	}
+	return operands
+}
+
+// indexable returns true unless the predicate is, or combines by Or, a predicate whose error policy is not
+// RejectOnError, as such a predicate may accept requests whose constraint can not be extracted.
+func indexable(p predicate.Predicate) bool {
+	switch pred := p.(type) {
+	case predicate.DescribedPredicate:
+		return indexable(pred.Predicate)
+	case predicate.ExtractedValuePredicate:
+		return pred.OnError == predicate.RejectOnError
+	case predicate.OrPredicate:
+		for _, operand := range pred {
+			if !indexable(operand) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hostsOf returns the host names accepted by a HostnameEquals predicate, a HostnameIn predicate without wildcards or
+// an Or of them, or nil if the predicate is anything else.
+func hostsOf(p predicate.Predicate) []string {
+	switch pred := p.(type) {
+	case predicate.DescribedPredicate:
+		if pred.Name != "HostnameIn" && (pred.Name != "HostnameEquals" || len(pred.Args) != 1) {
+			return nil
+		}
+		hosts := []string{}
+		for _, arg := range pred.Args {
+			host, ok := arg.(string)
+			if !ok || strings.HasPrefix(host, "*.") {
+				return nil
+			}
+			hosts = append(hosts, extractor.NormalizeHost(host))
+		}
+		return hosts
+	case predicate.OrPredicate:
+		hosts := []string{}
+		for _, operand := range pred {
+			h := hostsOf(operand)
+			if h == nil {
+				return nil
+			}
+			hosts = append(hosts, h...)
+		}
+		return hosts
+	}
+	return nil
+}
+
+// pathOf returns the path of a PathEquals or PathStartsWith predicate and whether it must be equal to the path of the
+// request.
+func pathOf(p predicate.Predicate) (string, bool, bool) {
+	pred, ok := p.(predicate.DescribedPredicate)
+	if !ok || len(pred.Args) != 1 || (pred.Name != "PathEquals" && pred.Name != "PathStartsWith") {
+		return "", false, false
+	}
+	path, ok := pred.Args[0].(string)
+	return path, pred.Name == "PathEquals", ok
+}
+
+// radixNode is a node of a radix tree of paths.  The key of a node is the concatenation of the labels of the nodes
+// from the root to it.
+type radixNode struct {
+	label    string
+	children []*radixNode
+	// exact holds the predicates that require the path to equal the key, prefix the ones that require the path to
+	// start with it.
+	exact  []int
+	prefix []int
+}
+
+// insert adds the predicate to the node whose key is the path, splitting nodes as needed.
+func (n *radixNode) insert(path string, i int, exact bool) {
+	for {
+		if path == "" {
+			if exact {
+				n.exact = append(n.exact, i)
+			} else {
+				n.prefix = append(n.prefix, i)
+			}
+			return
+		}
+		child := n.child(path[0])
+		if child == nil {
+			child = &radixNode{label: path}
+			n.children = append(n.children, child)
+			sort.Slice(n.children, func(a, b int) bool { return n.children[a].label < n.children[b].label })
+			n = child
+			path = ""
+			continue
+		}
+		common := commonPrefix(child.label, path)
+		if common < len(child.label) {
+			split := &radixNode{label: child.label[common:], children: child.children, exact: child.exact,
+				prefix: child.prefix}
+			*child = radixNode{label: child.label[:common], children: []*radixNode{split}}
+		}
+		n, path = child, path[common:]
+	}
+}
+
+// lookup appends to candidates the predicates of the nodes whose keys are prefixes of the path and those of the node
+// whose key is the path if they require it to be equal.
+func (n *radixNode) lookup(path string, candidates []int) []int {
+	for {
+		candidates = append(candidates, n.prefix...)
+		if path == "" {
+			return append(candidates, n.exact...)
+		}
+		child := n.child(path[0])
+		if child == nil || !strings.HasPrefix(path, child.label) {
+			return candidates
+		}
+		n, path = child, path[len(child.label):]
+	}
+}
+
+// child returns the child whose label starts with the byte, or nil if there is none.
+func (n *radixNode) child(b byte) *radixNode {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	if i < len(n.children) && n.children[i].label[0] == b {
+		return n.children[i]
+	}
+	return nil
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
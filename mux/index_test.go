@@ -0,0 +1,186 @@
+package mux_test
+
+import (
+	"fmt"
+	. "github.com/danapsimer/go-http-matchers/mux"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func linearMatch(predicates []predicate.Predicate, r *http.Request) int {
+	for i, p := range predicates {
+		if p.Accept(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestIndex_Match(t *testing.T) {
+	predicates := []predicate.Predicate{
+		predicate.And(predicate.MethodIs("get"), predicate.PathEquals("/orders")),
+		predicate.And(predicate.Or(predicate.MethodIs("POST"), predicate.MethodIs("PUT")),
+			predicate.And(predicate.PathStartsWith("/orders/"), predicate.HeaderEquals("X-Tenant", "a"))),
+		predicate.And(predicate.HostnameEquals("API.Example.com"), predicate.PathStartsWith("/orders")),
+		predicate.And(predicate.HostnameIn("*.example.com"), predicate.PathStartsWith("/")),
+		predicate.WithErrorPolicy(predicate.And(predicate.HostnameIn("shop.example.com"), predicate.MethodIs("DELETE")),
+			predicate.AcceptOnError),
+		predicate.PathStartsWith("/orders/"),
+		predicate.True(),
+	}
+	idx := NewIndex(predicates...)
+	assert.Equal(t, 7, idx.Len())
+
+	tests := []struct {
+		method, target, host string
+		header               http.Header
+		expected             int
+	}{
+		{"GET", "/orders", "example.com", nil, 0},
+		{"PUT", "/orders/42", "example.com", http.Header{"X-Tenant": {"a"}}, 1},
+		{"PUT", "/orders/42", "example.com", nil, 5},
+		{"GET", "/orders/42", "api.example.com:8080", nil, 2},
+		{"GET", "/ordersx", "api.example.com", nil, 2},
+		{"GET", "/", "www.example.com", nil, 3},
+		{"DELETE", "/", "shop.example.com", nil, 3},
+		{"DELETE", "/", "", nil, 4},
+		{"PATCH", "/other", "example.com", nil, 6},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.target, nil)
+		req.Host = test.host
+		for name, values := range test.header {
+			req.Header[name] = values
+		}
+		assert.Equal(t, test.expected, idx.Match(req), "%s %s %s", test.method, test.host, test.target)
+		assert.Equal(t, linearMatch(predicates, req), idx.Match(req), "%s %s %s", test.method, test.host, test.target)
+	}
+
+	assert.Equal(t, -1, NewIndex().Match(httptest.NewRequest("GET", "/", nil)))
+}
+
+var (
+	methods = []string{"GET", "POST", "PUT", "DELETE", "get"}
+	hosts   = []string{"example.com", "api.example.com", "API.example.com.", "*.example.com", "other.org"}
+	paths   = []string{"", "/", "/a", "/a/", "/a/b", "/ab", "/a/b/c", "/b", "/b/a", "/abc"}
+)
+
+func pick(rnd *rand.Rand, values []string) string {
+	return values[rnd.Intn(len(values))]
+}
+
+// randomPredicate builds a route predicate out of indexable constraints, combined in the ways the index understands
+// and the ways it does not, and residual predicates.
+func randomPredicate(rnd *rand.Rand) predicate.Predicate {
+	var operands []predicate.Predicate
+	for n := rnd.Intn(5); n > 0; n-- {
+		switch rnd.Intn(10) {
+		case 0:
+			operands = append(operands, predicate.MethodIs(pick(rnd, methods)))
+		case 1:
+			operands = append(operands, predicate.Or(predicate.MethodIs(pick(rnd, methods)),
+				predicate.MethodIs(pick(rnd, methods))))
+		case 2:
+			operands = append(operands, predicate.HostnameEquals(pick(rnd, hosts)))
+		case 3:
+			operands = append(operands, predicate.HostnameIn(pick(rnd, hosts), pick(rnd, hosts)))
+		case 4:
+			operands = append(operands, predicate.PathEquals(pick(rnd, paths)))
+		case 5:
+			operands = append(operands, predicate.PathStartsWith(pick(rnd, paths)))
+		case 6:
+			operands = append(operands, predicate.HeaderEquals("X-Flag", "on"))
+		case 7:
+			operands = append(operands, predicate.Not(predicate.PathStartsWith(pick(rnd, paths))))
+		case 8:
+			operands = append(operands, predicate.And(predicate.MethodIs(pick(rnd, methods)),
+				predicate.PathStartsWith(pick(rnd, paths))))
+		case 9:
+			operands = append(operands, predicate.Or(predicate.PathEquals(pick(rnd, paths)),
+				predicate.HostnameEquals(pick(rnd, hosts))))
+		}
+	}
+	var p predicate.Predicate = predicate.And(operands...)
+	if len(operands) == 1 && rnd.Intn(2) == 0 {
+		p = operands[0]
+	}
+	if rnd.Intn(10) == 0 {
+		p = predicate.WithErrorPolicy(p, predicate.AcceptOnError)
+	}
+	return p
+}
+
+func TestIndex_MatchesLinearOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		predicates := make([]predicate.Predicate, 1+rnd.Intn(40))
+		for i := range predicates {
+			predicates[i] = randomPredicate(rnd)
+		}
+		idx := NewIndex(predicates...)
+		for n := 0; n < 200; n++ {
+			req := httptest.NewRequest(pick(rnd, methods[:4]), "/", nil)
+			req.URL.Path = pick(rnd, paths) + pick(rnd, []string{"", "c", "/c"})
+			req.Host = pick(rnd, append([]string{"", "www.example.com:443"}, hosts...))
+			if rnd.Intn(2) == 0 {
+				req.Header.Set("X-Flag", "on")
+			}
+			expected := linearMatch(predicates, req)
+			if !assert.Equal(t, expected, idx.Match(req), "%s %s %s", req.Method, req.Host, req.URL.Path) {
+				for i, p := range predicates {
+					t.Logf("%d: %v", i, p)
+				}
+				return
+			}
+		}
+	}
+}
+
+func TestMux_IndexUpdatedOnHandle(t *testing.T) {
+	m := NewMux()
+	m.Handle(predicate.PathStartsWith("/api/"), respond("api"))
+	assert.Equal(t, "api", serve(m, "GET", "/api/orders").Body.String())
+	m.HandleWithPriority(1, predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/api/orders")),
+		respond("orders"))
+	assert.Equal(t, "orders", serve(m, "GET", "/api/orders").Body.String())
+	assert.Equal(t, "api", serve(m, "POST", "/api/orders").Body.String())
+}
+
+func benchmarkRoutes() ([]predicate.Predicate, []*http.Request) {
+	var predicates []predicate.Predicate
+	for i := 0; i < 2000; i++ {
+		predicates = append(predicates, predicate.And(predicate.MethodIs(methods[i%4]),
+			predicate.PathStartsWith(fmt.Sprintf("/service%d/", i)), predicate.HeaderExists("Authorization")))
+	}
+	requests := []*http.Request{
+		httptest.NewRequest("GET", "/service0/a", nil),
+		httptest.NewRequest("POST", "/service1001/a", nil),
+		httptest.NewRequest("DELETE", "/service1999/a", nil),
+		httptest.NewRequest("GET", "/unknown", nil),
+	}
+	for _, req := range requests {
+		req.Header.Set("Authorization", "Bearer x")
+	}
+	return predicates, requests
+}
+
+func BenchmarkLinearMatch(b *testing.B) {
+	predicates, requests := benchmarkRoutes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linearMatch(predicates, requests[i%len(requests)])
+	}
+}
+
+func BenchmarkIndex_Match(b *testing.B) {
+	predicates, requests := benchmarkRoutes()
+	idx := NewIndex(predicates...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Match(requests[i%len(requests)])
+	}
+}
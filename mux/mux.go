@@ -0,0 +1,300 @@
+// Package mux provides Mux, an http.Handler that routes requests to handlers using predicates.  Routes are tried in
+// order of descending priority and, within the same priority, in the order they were registered.  The first route whose
+// predicate accepts the request serves it:
+//
+//	m := mux.NewMux()
+//	m.Handle(predicate.And(predicate.MethodIs("GET"), predicate.PathStartsWith("/api/")), apiHandler)
+//	m.HandleWithPriority(10, predicate.HeaderEquals("X-Canary", "true"), canaryHandler)
+//	http.ListenAndServe(":8080", m)
+//
+// If no route accepts the request, but a route would have accepted it had its MethodIs predicate accepted the
+// request's method, the Mux responds with 405 Method Not Allowed and an Allow header listing the methods those routes
+// accept.  Otherwise it responds with 404 Not Found.
+//
+// Matching requests does not test the routes one after the other: the routes are indexed by the methods, host names and
+// paths their predicates require, see Index, so that only the routes that can accept a request are tested.  The route
+// that serves the request is still the first one, in the order above, whose predicate accepts it.
+//
+// The parameters captured by the PathTemplate predicates of the route that serves a request are stored in the
+// request's context, where the handler reads them with extractor.PathParameters or extractor.PathParameter.
+package mux
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mux is an http.Handler that dispatches requests to the handler of the first route whose predicate accepts the
+// request.
+type Mux struct {
+	// NotFound serves requests that no route accepts.  If nil, a 404 Not Found response is written.
+	NotFound http.Handler
+	// MethodNotAllowed serves requests that a route would accept with a different method.  The Allow header has been
+	// set when it is called.  If nil, a 405 Method Not Allowed response is written.
+	MethodNotAllowed http.Handler
+	// Debug adds an explanation of why each route rejected the request to the body of the default 404 and 405
+	// responses.
+	Debug bool
+
+	mu     sync.RWMutex
+	routes []*Route
+	// index indexes the predicates of indexedRoutes, a copy of routes.  It is built by Match and discarded when a route
+	// is registered.
+	index         *Index
+	indexedRoutes []*Route
+}
+
+// Route is a predicate and the handler that serves the requests it accepts.
+type Route struct {
+	Predicate predicate.Predicate
+	Handler   http.Handler
+	Priority  int
+
+	// methods are the methods accepted by the MethodIs predicates found at the top level of Predicate and rest is
+	// what remains of the predicate once they are removed.
+	methods []string
+	rest    predicate.Predicate
+	// templates are the templates of the PathTemplate predicates that must accept a request for Predicate to accept
+	// it.
+	templates []*extractor.PathTemplate
+}
+
+// ServeHTTP stores the path parameters captured by the route's PathTemplate predicates in the request's context and
+// calls the route's handler.
+func (route *Route) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(route.templates) > 0 {
+		params := map[string]string{}
+		for _, pt := range route.templates {
+			captured, _ := pt.Match(r.URL.Path)
+			for name, value := range captured {
+				params[name] = value
+			}
+		}
+		r = extractor.WithPathParameters(r, params)
+	}
+	route.Handler.ServeHTTP(w, r)
+}
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux {
+	return &Mux{}
+}
+
+// Handle registers the handler for the requests accepted by the predicate with a priority of 0.
+func (m *Mux) Handle(p predicate.Predicate, handler http.Handler) *Route {
+	return m.HandleWithPriority(0, p, handler)
+}
+
+// HandleFunc registers the handler function for the requests accepted by the predicate with a priority of 0.
+func (m *Mux) HandleFunc(p predicate.Predicate, handler func(http.ResponseWriter, *http.Request)) *Route {
+	return m.Handle(p, http.HandlerFunc(handler))
+}
+
+// HandleWithPriority registers the handler for the requests accepted by the predicate.  Routes with a higher priority
+// are tried before routes with a lower one.
+func (m *Mux) HandleWithPriority(priority int, p predicate.Predicate, handler http.Handler) *Route {
+	route := &Route{Predicate: p, Handler: handler, Priority: priority}
+	route.methods, route.rest = splitMethods(p)
+	route.templates = templatesOf(p)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = append(m.routes, route)
+	m.index = nil
+	sort.SliceStable(m.routes, func(i, j int) bool {
+		return m.routes[i].Priority > m.routes[j].Priority
+	})
+	return route
+}
+
+// Routes returns the registered routes in the order they are tried.
+func (m *Mux) Routes() []*Route {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]*Route(nil), m.routes...)
+}
+
+// Match returns the first route that accepts the request or nil if there is none.
+func (m *Mux) Match(r *http.Request) *Route {
+	routes, index := m.indexed()
+	if i := index.Match(r); i >= 0 {
+		return routes[i]
+	}
+	return nil
+}
+
+// indexed returns the routes and their index, building it if the routes changed since it was last built.
+func (m *Mux) indexed() ([]*Route, *Index) {
+	m.mu.RLock()
+	routes, index := m.indexedRoutes, m.index
+	m.mu.RUnlock()
+	if index != nil {
+		return routes, index
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.index == nil {
+		m.indexedRoutes = append([]*Route(nil), m.routes...)
+		predicates := make([]predicate.Predicate, 0, len(m.indexedRoutes))
+		for _, route := range m.indexedRoutes {
+			predicates = append(predicates, route.Predicate)
+		}
+		m.index = NewIndex(predicates...)
+	}
+	return m.indexedRoutes, m.index
+}
+
+// ServeHTTP dispatches the request to the handler of the first route that accepts it.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if route := m.Match(r); route != nil {
+		route.ServeHTTP(w, r)
+		return
+	}
+	if allowed := m.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		if m.MethodNotAllowed != nil {
+			m.MethodNotAllowed.ServeHTTP(w, r)
+			return
+		}
+		m.writeError(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+	if m.NotFound != nil {
+		m.NotFound.ServeHTTP(w, r)
+		return
+	}
+	m.writeError(w, r, http.StatusNotFound)
+}
+
+func (m *Mux) writeError(w http.ResponseWriter, r *http.Request, status int) {
+	message := http.StatusText(status)
+	if m.Debug {
+		message += "\n\n" + strings.TrimSuffix(m.Explain(r), "\n")
+	}
+	http.Error(w, message, status)
+}
+
+// Explain returns an explanation of why each route accepted or rejected the request, in the order the routes are
+// tried.
+func (m *Mux) Explain(r *http.Request) string {
+	sb := &strings.Builder{}
+	for i, route := range m.Routes() {
+		accepted, explanation := predicate.Explain(route.Predicate, r)
+		fmt.Fprintf(sb, "route %d: %v\n", i+1, route.Predicate)
+		if accepted {
+			sb.WriteString("  accepted\n")
+			continue
+		}
+		for _, line := range strings.Split(explanation, "\n") {
+			sb.WriteString("  " + line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// allowedMethods returns the methods of the routes that reject the request only because of its method.
+func (m *Mux) allowedMethods(r *http.Request) []string {
+	var allowed []string
+	seen := map[string]bool{}
+	for _, route := range m.Routes() {
+		if len(route.methods) == 0 || !route.rest.Accept(r) {
+			continue
+		}
+		for _, method := range route.methods {
+			if !seen[method] {
+				seen[method] = true
+				allowed = append(allowed, method)
+			}
+		}
+	}
+	return allowed
+}
+
+// splitMethods looks for MethodIs predicates at the top level of the predicate, either alone, combined by Or, or as
+// operands of an And.  It returns the methods they accept and the predicate that remains once they are removed.
+func splitMethods(p predicate.Predicate) ([]string, predicate.Predicate) {
+	if methods := methodsOf(p); methods != nil {
+		return methods, predicate.True()
+	}
+	and, ok := p.(predicate.AndPredicate)
+	if !ok {
+		return nil, p
+	}
+	var methods []string
+	var rest []predicate.Predicate
+	found := false
+	for _, operand := range and {
+		if m := methodsOf(operand); m != nil {
+			if found {
+				// The intersection of several method constraints is not worth the trouble, don't detect 405s.
+				return nil, p
+			}
+			methods, found = m, true
+			continue
+		}
+		rest = append(rest, operand)
+	}
+	if !found {
+		return nil, p
+	}
+	return methods, predicate.And(rest...)
+}
+
+// methodsOf returns the methods accepted by a MethodIs predicate or an Or of MethodIs predicates, or nil if the
+// predicate is anything else.
+func methodsOf(p predicate.Predicate) []string {
+	switch pred := p.(type) {
+	case predicate.DescribedPredicate:
+		if pred.Name == "MethodIs" && len(pred.Args) == 1 {
+			if method, ok := pred.Args[0].(string); ok {
+				return []string{strings.ToUpper(method)}
+			}
+		}
+	case predicate.OrPredicate:
+		var methods []string
+		for _, operand := range pred {
+			m := methodsOf(operand)
+			if m == nil {
+				return nil
+			}
+			methods = append(methods, m...)
+		}
+		return methods
+	}
+	return nil
+}
+
+// templatesOf returns the templates of the PathTemplate predicates found in the predicate, either alone or as operands
+// of an And, possibly nested.
+func templatesOf(p predicate.Predicate) []*extractor.PathTemplate {
+	switch pred := p.(type) {
+	case predicate.DescribedPredicate:
+		if pred.Name == "PathTemplate" && len(pred.Args) == 1 {
+			if template, ok := pred.Args[0].(string); ok {
+				return []*extractor.PathTemplate{extractor.MustCompilePathTemplate(template)}
+			}
+		}
+	case predicate.AndPredicate:
+		var templates []*extractor.PathTemplate
+		for _, operand := range pred {
+			templates = append(templates, templatesOf(operand)...)
+		}
+		return templates
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package mux_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/mux"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+func ExampleMux() {
+	m := mux.NewMux()
+	m.HandleFunc(predicate.And(predicate.MethodIs("GET"), predicate.PathStartsWith("/api/")),
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "api")
+		})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/api/orders", nil),
+		httptest.NewRequest("DELETE", "/api/orders", nil),
+		httptest.NewRequest("GET", "/other", nil),
+	} {
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, req)
+		fmt.Println(req.Method, req.URL.Path, w.Code, w.Header()["Allow"])
+	}
+	// Output:
+	// GET /api/orders 200 []
+	// DELETE /api/orders 405 [GET]
+	// GET /other 404 []
+}
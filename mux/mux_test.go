@@ -0,0 +1,138 @@
+package mux_test
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/mux"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func respond(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	})
+}
+
+func serve(m http.Handler, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestMux_FirstMatch(t *testing.T) {
+	m := NewMux()
+	m.Handle(predicate.And(predicate.MethodIs("GET"), predicate.PathStartsWith("/api/")), respond("api"))
+	m.Handle(predicate.PathStartsWith("/api/orders"), respond("orders"))
+	m.HandleFunc(predicate.True(), func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "fallback")
+	})
+
+	assert.Equal(t, "api", serve(m, "GET", "/api/orders").Body.String())
+	assert.Equal(t, "orders", serve(m, "POST", "/api/orders").Body.String())
+	assert.Equal(t, "fallback", serve(m, "POST", "/api/users").Body.String())
+}
+
+func TestMux_Priority(t *testing.T) {
+	m := NewMux()
+	m.Handle(predicate.PathStartsWith("/"), respond("low"))
+	m.HandleWithPriority(10, predicate.HeaderEquals("X-Canary", "true"), respond("canary"))
+	m.HandleWithPriority(10, predicate.PathEquals("/special"), respond("special"))
+	m.HandleWithPriority(-1, predicate.True(), respond("never"))
+
+	req := httptest.NewRequest("GET", "/special", nil)
+	req.Header.Set("X-Canary", "true")
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, req)
+	assert.Equal(t, "canary", w.Body.String())
+	assert.Equal(t, "special", serve(m, "GET", "/special").Body.String())
+	assert.Equal(t, "low", serve(m, "GET", "/other").Body.String())
+
+	routes := m.Routes()
+	if assert.Len(t, routes, 4) {
+		assert.Equal(t, []int{10, 10, 0, -1}, []int{routes[0].Priority, routes[1].Priority, routes[2].Priority,
+			routes[3].Priority})
+	}
+}
+
+func TestMux_NotFound(t *testing.T) {
+	m := NewMux()
+	m.Handle(predicate.PathEquals("/a"), respond("a"))
+
+	w := serve(m, "GET", "/b")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "Not Found\n", w.Body.String())
+
+	m.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	assert.Equal(t, http.StatusTeapot, serve(m, "GET", "/b").Code)
+}
+
+func TestMux_MethodNotAllowed(t *testing.T) {
+	m := NewMux()
+	m.Handle(predicate.And(predicate.MethodIs("get"), predicate.PathEquals("/a")), respond("get a"))
+	m.Handle(predicate.And(predicate.PathEquals("/a"), predicate.Or(predicate.MethodIs("PUT"),
+		predicate.MethodIs("PATCH"))), respond("put a"))
+	m.Handle(predicate.And(predicate.MethodIs("DELETE"), predicate.PathEquals("/b")), respond("delete b"))
+
+	w := serve(m, "POST", "/a")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, PUT, PATCH", w.Header().Get("Allow"))
+
+	w = serve(m, "POST", "/c")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "", w.Header().Get("Allow"))
+
+	m.MethodNotAllowed = respond("custom")
+	w = serve(m, "GET", "/b")
+	assert.Equal(t, "custom", w.Body.String())
+	assert.Equal(t, "DELETE", w.Header().Get("Allow"))
+}
+
+func TestMux_Debug(t *testing.T) {
+	m := NewMux()
+	m.Debug = true
+	m.Handle(predicate.And(predicate.MethodIs("GET"), predicate.PathEquals("/a")), respond("a"))
+	m.Handle(predicate.HeaderEquals("X-Tenant", "b"), respond("b"))
+
+	w := serve(m, "GET", "/c")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `Not Found
+
+route 1: And(MethodIs("GET"), PathEquals("/a"))
+  path: expected '/a', got '/c'
+route 2: HeaderEquals("X-Tenant", "b")
+  header X-Tenant: expected 'b', got ''
+`, w.Body.String())
+
+	w = serve(m, "POST", "/a")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), "upper case method: expected 'GET', got 'POST'"), w.Body.String())
+}
+
+func TestMux_Match(t *testing.T) {
+	m := NewMux()
+	route := m.Handle(predicate.PathEquals("/a"), respond("a"))
+	assert.True(t, route == m.Match(httptest.NewRequest("GET", "/a", nil)))
+	assert.Nil(t, m.Match(httptest.NewRequest("GET", "/b", nil)))
+}
+
+func TestMux_PathParameters(t *testing.T) {
+	m := NewMux()
+	m.HandleFunc(predicate.And(predicate.MethodIs("GET"), predicate.PathTemplate("/users/{id}/orders/{orderId:[0-9]+}")),
+		func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, extractor.PathParameter(r, "id")+" "+extractor.PathParameter(r, "orderId"))
+		})
+	m.HandleFunc(predicate.PathStartsWith("/"), func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, extractor.PathParameters(r))
+		io.WriteString(w, "fallback")
+	})
+
+	assert.Equal(t, "ann 7", serve(m, "GET", "/users/ann/orders/7").Body.String())
+	assert.Equal(t, "fallback", serve(m, "GET", "/users/ann/orders/x").Body.String())
+}
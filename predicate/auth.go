@@ -0,0 +1,138 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"time"
+)
+
+// JWTPredicate is the Predicate returned by TokenNotExpired.  It tests an *extractor.JWT, like the one returned by
+// extractor.ExtractJWT, against the time returned by Clock.  Operator is "is not expired".  Any other value is
+// rejected.
+type JWTPredicate struct {
+	Operator string
+	Clock    func() time.Time
+	Func     func(*extractor.JWT, time.Time) bool
+}
+
+// TokenNotExpired returns a predicate that returns true if the JWT has not expired at the time returned by the clock,
+// i.e. it has no exp claim or its exp claim is after that time.  A nil clock is time.Now.
+func TokenNotExpired(clock func() time.Time) Predicate {
+	if clock == nil {
+		clock = time.Now
+	}
+	return JWTPredicate{Operator: "is not expired", Clock: clock, Func: func(jwt *extractor.JWT, now time.Time) bool {
+		claim, ok := jwt.Claims["exp"]
+		if !ok {
+			return true
+		}
+		exp, ok := claim.(float64)
+		return ok && now.Before(time.Unix(int64(exp), 0))
+	}}
+}
+
+// Accept returns true if the value passed is a JWT that satisfies the test.
+func (jp JWTPredicate) Accept(v interface{}) bool {
+	accepted, err := jp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a non nil *extractor.JWT.
+func (jp JWTPredicate) AcceptE(v interface{}) (bool, error) {
+	return jp.test(v, jp.Clock())
+}
+
+func (jp JWTPredicate) test(v interface{}, now time.Time) (bool, error) {
+	jwt, ok := v.(*extractor.JWT)
+	if !ok || jwt == nil {
+		return false, fmt.Errorf("%w: expected an *extractor.JWT, got %T", extractor.ErrWrongType, v)
+	}
+	return jp.Func(jwt, now), nil
+}
+
+// Evaluate tests the JWT and records the test made along with the time it was tested against.
+func (jp JWTPredicate) Evaluate(v interface{}) *Result {
+	now := jp.Clock()
+	accepted, err := jp.test(v, now)
+	return &Result{Predicate: jp, Accepted: accepted, Value: v, Operator: jp.Operator, Expected: now, Err: err}
+}
+
+var jwtPredicateNames = map[string]string{
+	"is not expired": "TokenNotExpired",
+}
+
+// Describe returns the name of the function that built the predicate.  The clock is not described.
+func (jp JWTPredicate) Describe() Description {
+	return Description{Name: jwtPredicateNames[jp.Operator]}
+}
+
+// String renders the predicate as a function call, e.g. TokenNotExpired().
+func (jp JWTPredicate) String() string {
+	return jp.Describe().String()
+}
+
+// BasicAuthUser returns a predicate that returns true if the username of the Basic credentials of the request equals
+// 'user'.
+func BasicAuthUser(user string) Predicate {
+	return describe("BasicAuthUser", ExtractedValueAccepted(extractor.ExtractBasicAuthUser(), StringEquals(user)), user)
+}
+
+// BasicAuth returns a predicate that returns true if the request has Basic credentials with the username 'user' and
+// the password 'password'.  Beware that the password is part of the description of the predicate.
+func BasicAuth(user, password string) Predicate {
+	return describe("BasicAuth", And(
+		ExtractedValueAccepted(extractor.ExtractBasicAuthUser(), StringEquals(user)),
+		ExtractedValueAccepted(extractor.ExtractBasicAuthPassword(), StringEquals(password)),
+	), user, password)
+}
+
+// BearerTokenEquals returns a predicate that returns true if the token of the Bearer credentials of the request equals
+// 'token'.
+func BearerTokenEquals(token string) Predicate {
+	return describe("BearerTokenEquals", ExtractedValueAccepted(extractor.ExtractBearerToken(), StringEquals(token)),
+		token)
+}
+
+// JWTClaimEquals returns a predicate that returns true if the named claim of the JWT of the Bearer credentials of the
+// request equals 'value'.  See JSONEquals for how the values are compared.  The signature of the token is verified
+// with the keys, or with extractor.DefaultJWTKeys if none are given and it is set.  See extractor.JWT.Verify for the
+// types of keys supported.  The keys are described as the extractor.Opaque <keys>, so a predicate built with keys
+// can not be encoded.
+func JWTClaimEquals(name string, value interface{}, keys ...interface{}) Predicate {
+	p := ExtractedValueAccepted(extractor.ExtractJWTClaim(name, keys...), JSONEquals(value))
+	return describe("JWTClaimEquals", p, withKeys(keys, name, value)...)
+}
+
+// JWTHasScope returns a predicate that returns true if the JWT of the Bearer credentials of the request grants the
+// scope.  See extractor.JWT.Scopes.  The signature of the token is verified as it is by JWTClaimEquals.
+func JWTHasScope(scope string, keys ...interface{}) Predicate {
+	return describe("JWTHasScope", ExtractedValueAccepted(extractor.ExtractJWTScopes(keys...), ContainsAll(scope)),
+		withKeys(keys, scope)...)
+}
+
+// JWTNotExpired returns a predicate that returns true if the JWT of the Bearer credentials of the request has not
+// expired at the time returned by the clock.  A nil clock is time.Now.  The clock is not described, so a decoded
+// JWTNotExpired uses time.Now.  The signature of the token is verified as it is by JWTClaimEquals.
+func JWTNotExpired(clock func() time.Time, keys ...interface{}) Predicate {
+	return describe("JWTNotExpired", ExtractedValueAccepted(extractor.ExtractJWT(keys...), TokenNotExpired(clock)),
+		withKeys(keys)...)
+}
+
+// withKeys returns the arguments followed, if there are keys, by the extractor.Opaque <keys>.
+func withKeys(keys []interface{}, args ...interface{}) []interface{} {
+	if len(keys) > 0 {
+		args = append(args, extractor.Opaque("keys"))
+	}
+	return args
+}
@@ -0,0 +1,87 @@
+package predicate_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func bearerRequest(t *testing.T, secret string, claims map[string]interface{}) *http.Request {
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+	input := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Authorization", "Bearer "+input+"."+base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestBasicAuthPredicates(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.SetBasicAuth("admin", "secret")
+
+	assert.True(t, BasicAuthUser("admin").Accept(req))
+	assert.False(t, BasicAuthUser("guest").Accept(req))
+	assert.True(t, BasicAuth("admin", "secret").Accept(req))
+	assert.False(t, BasicAuth("admin", "guess").Accept(req))
+	assert.False(t, BearerTokenEquals("secret").Accept(req))
+
+	req.Header.Set("Authorization", "Bearer opaque")
+	assert.True(t, BearerTokenEquals("opaque").Accept(req))
+	accepted, explanation := Explain(BasicAuthUser("admin"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "basic auth user: value missing: Authorization header does not hold Basic credentials", explanation)
+}
+
+func TestJWTPredicates(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	req := bearerRequest(t, "secret", map[string]interface{}{
+		"sub":   "u1",
+		"admin": true,
+		"level": 3,
+		"scope": "read write",
+		"exp":   now.Add(time.Minute).Unix(),
+	})
+
+	assert.True(t, JWTClaimEquals("sub", "u1").Accept(req))
+	assert.True(t, JWTClaimEquals("admin", true).Accept(req))
+	assert.True(t, JWTClaimEquals("level", 3).Accept(req))
+	assert.False(t, JWTClaimEquals("sub", "u2").Accept(req))
+	assert.False(t, JWTClaimEquals("aud", "x").Accept(req))
+	assert.True(t, JWTHasScope("read").Accept(req))
+	assert.False(t, JWTHasScope("admin").Accept(req))
+	assert.True(t, JWTNotExpired(clock).Accept(req))
+	assert.True(t, JWTNotExpired(nil).Accept(bearerRequest(t, "secret", map[string]interface{}{"sub": "u1"})))
+
+	later := func() time.Time { return now.Add(time.Hour) }
+	accepted, explanation := Explain(JWTNotExpired(later), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "jwt: expected a token that has not expired at '"+later().String()+"', got "+
+		`'{"admin":true,"exp":1714564860,"level":3,"scope":"read write","sub":"u1"}'`, explanation)
+
+	assert.True(t, JWTClaimEquals("sub", "u1", "secret").Accept(req))
+	assert.False(t, JWTClaimEquals("sub", "u1", "other").Accept(req))
+	assert.True(t, JWTHasScope("read", "other", []byte("secret")).Accept(req))
+	assert.False(t, JWTHasScope("read", "other").Accept(req))
+	assert.True(t, JWTNotExpired(clock, "secret").Accept(req))
+	assert.False(t, JWTNotExpired(clock, "other").Accept(req))
+	_, err := AcceptE(WithErrorPolicy(Not(JWTClaimEquals("sub", "u1", "other")), PropagateError), req)
+	assert.True(t, errors.Is(err, extractor.ErrSignature), "%v", err)
+	assert.Equal(t, `JWTClaimEquals("sub", "u1", <keys>)`, fmt.Sprint(JWTClaimEquals("sub", "u1", "secret")))
+	assert.Equal(t, `JWTHasScope("read", <keys>)`, fmt.Sprint(JWTHasScope("read", "secret")))
+	assert.Equal(t, `JWTNotExpired()`, JWTNotExpired(clock).(DescribedPredicate).String())
+}
@@ -9,17 +9,28 @@ import (
 // BodyXPathEquals checks to see if the result of the xpath expression, matches the string supplied in the 'value'
 // parameter.
 func BodyXPathEquals(xpath, value string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractXPathString(xpath), StringEquals(value))
+	return describe("BodyXPathEquals",
+		ExtractedValueAccepted(extractor.ExtractXPathString(xpath), StringEquals(value)),
+		xpath, value)
 }
 
 // BodyXPathEqualsIgnoreCase similar to BodyXPathEquals but ignores case when comparing the strings.
 func BodyXPathEqualsIgnoreCase(xpath, value string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractXPathString(xpath)),
-		StringEquals(strings.ToUpper(value)))
+	return describe("BodyXPathEqualsIgnoreCase", ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractXPathString(xpath)),
+		StringEquals(strings.ToUpper(value))), xpath, value)
 }
 
 // BodyXPathMatches checks to see if the result of the xpath expression, matches the regular expression given in the
 // 'pattern' parameter.
 func BodyXPathMatches(xpath string, pattern *regexp.Regexp) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractXPathString(xpath), StringMatches(pattern))
+	return describe("BodyXPathMatches",
+		ExtractedValueAccepted(extractor.ExtractXPathString(xpath), StringMatches(pattern)),
+		xpath, pattern)
+}
+
+// BodyXPathExists checks to see if the xpath expression selects anything from the body, even an empty element.
+func BodyXPathExists(xpath string) Predicate {
+	return describe("BodyXPathExists",
+		ExtractedValueAccepted(extractor.ExtractOptionalXPathString(xpath), IsPresent()),
+		xpath)
 }
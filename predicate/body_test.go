@@ -20,6 +20,8 @@ var bodyTests = []struct {
 	{"EqualsIgnoreCase No Match", BodyXPathEqualsIgnoreCase("/snafu/foo", "Baz"), false},
 	{"Matches Match", BodyXPathMatches("/snafu/foo", regexp.MustCompile("b[aeiou]r")), true},
 	{"Matches No Match", BodyXPathMatches("/snafu/foo", regexp.MustCompile("b[aeiou]z")), false},
+	{"Exists Match", BodyXPathExists("/snafu/foo"), true},
+	{"Exists No Match", BodyXPathExists("/snafu/missing"), false},
 }
 
 func TestBodyXPath(t *testing.T) {
@@ -39,3 +41,18 @@ func TestBodyXPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBodyXPath_MultiplePredicates(t *testing.T) {
+	testURL, _ := url.ParseRequestURI("http://localhost/foo")
+	body, err := os.Open("../testdata/response.xml")
+	if assert.NoError(t, err) {
+		request := &http.Request{
+			Method: "GET",
+			Header: http.Header{},
+			URL:    testURL,
+			Body:   body,
+		}
+		assert.True(t, And(BodyXPathEquals("/snafu/foo", "bar"), BodyXPathEqualsIgnoreCase("/snafu/foo", "BAR"),
+			BodyXPathMatches("/snafu/foo", regexp.MustCompile("b[aeiou]r"))).Accept(request))
+	}
+}
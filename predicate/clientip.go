@@ -0,0 +1,208 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"net/netip"
+)
+
+// IPPredicate is the Predicate returned by IPIn, IPInCIDR, IPIsPrivate and IPIsLoopback.  It tests an IP address,
+// either a netip.Addr, like the ones returned by extractor.ExtractClientIP, or a string holding one.  Operator is one
+// of "in", "is private" or "is loopback".  Expected is the extractor.IPSet the address must be in, if any.  Any other
+// value is rejected.
+type IPPredicate struct {
+	Operator string
+	Expected *extractor.IPSet
+	Func     func(netip.Addr) bool
+}
+
+// IPIn returns a predicate that returns true if the address is in the set.
+func IPIn(set *extractor.IPSet) Predicate {
+	return IPPredicate{Operator: "in", Expected: set, Func: set.Contains}
+}
+
+// IPInCIDR returns a predicate that returns true if the address is in one of the CIDR ranges, e.g. "10.0.0.0/8" or
+// "2001:db8::/32".  Single addresses are accepted as well.  IPInCIDR panics if a range can not be parsed.
+func IPInCIDR(cidrs ...string) Predicate {
+	return IPIn(extractor.MustIPSet(cidrs...))
+}
+
+// IPIsPrivate returns a predicate that returns true if the address is a private address as defined by RFC 1918 for
+// IPv4 and RFC 4193 for IPv6.
+func IPIsPrivate() Predicate {
+	return IPPredicate{Operator: "is private", Func: netip.Addr.IsPrivate}
+}
+
+// IPIsLoopback returns a predicate that returns true if the address is a loopback address, e.g. 127.0.0.1 or ::1.
+func IPIsLoopback() Predicate {
+	return IPPredicate{Operator: "is loopback", Func: netip.Addr.IsLoopback}
+}
+
+// Accept returns true if the value passed is an IP address that satisfies the test.
+func (ip IPPredicate) Accept(v interface{}) bool {
+	accepted, err := ip.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is neither a netip.Addr nor a string, and an
+// error wrapping extractor.ErrParse if it is a string that does not hold an IP address.
+func (ip IPPredicate) AcceptE(v interface{}) (bool, error) {
+	var addr netip.Addr
+	switch value := v.(type) {
+	case netip.Addr:
+		addr = value
+	case string:
+		var err error
+		if addr, err = netip.ParseAddr(value); err != nil {
+			return false, fmt.Errorf("%w: %v", extractor.ErrParse, err)
+		}
+	default:
+		return false, fmt.Errorf("%w: expected an IP address, got %T", extractor.ErrWrongType, v)
+	}
+	if !addr.IsValid() {
+		return false, nil
+	}
+	return ip.Func(addr.Unmap()), nil
+}
+
+// Evaluate tests the address and records the test made.
+func (ip IPPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := ip.AcceptE(v)
+	result := &Result{Predicate: ip, Accepted: accepted, Value: v, Operator: ip.Operator, Err: err}
+	if ip.Expected != nil {
+		result.Expected = ip.Expected
+	}
+	return result
+}
+
+var ipPredicateNames = map[string]string{
+	"in":          "IPInCIDR",
+	"is private":  "IPIsPrivate",
+	"is loopback": "IPIsLoopback",
+}
+
+// Describe returns the name of the function that built the predicate and its arguments.  A predicate built by IPIn is
+// described as the equivalent call to IPInCIDR.
+func (ip IPPredicate) Describe() Description {
+	description := Description{Name: ipPredicateNames[ip.Operator]}
+	if ip.Expected != nil {
+		for _, entry := range ip.Expected.Entries() {
+			description.Args = append(description.Args, entry)
+		}
+	}
+	return description
+}
+
+// String renders the predicate as a function call, e.g. IPInCIDR("10.0.0.0/8").
+func (ip IPPredicate) String() string {
+	return ip.Describe().String()
+}
+
+// ClientIPIn returns a predicate that returns true if the address of the client, as returned by
+// extractor.ExtractClientIP, is in the set.  Only extractor.DefaultTrustedProxies are trusted unless the predicate is
+// wrapped by WithTrustedProxies.  It is described by the entries of the set, e.g. ClientIPIn("10.0.0.0/8").
+func ClientIPIn(set *extractor.IPSet) Predicate {
+	return describe("ClientIPIn", ExtractedValueAccepted(extractor.ExtractClientIP(), IPIn(set)), ipSetArgs(set)...)
+}
+
+// ClientIPInCIDR returns a predicate that returns true if the address of the client, as returned by
+// extractor.ExtractClientIP, is in one of the CIDR ranges.  See ClientIPIn.  ClientIPInCIDR panics if a range can not
+// be parsed.
+func ClientIPInCIDR(cidrs ...string) Predicate {
+	set := extractor.MustIPSet(cidrs...)
+	return describe("ClientIPInCIDR", ExtractedValueAccepted(extractor.ExtractClientIP(), IPIn(set)), ipSetArgs(set)...)
+}
+
+func ipSetArgs(set *extractor.IPSet) []interface{} {
+	args := make([]interface{}, 0)
+	for _, entry := range set.Entries() {
+		args = append(args, entry)
+	}
+	return args
+}
+
+// ClientIPIsPrivate returns a predicate that returns true if the address of the client, as returned by
+// extractor.ExtractClientIP, is a private address.  See ClientIPIn.
+func ClientIPIsPrivate() Predicate {
+	return describe("ClientIPIsPrivate", ExtractedValueAccepted(extractor.ExtractClientIP(), IPIsPrivate()))
+}
+
+// ClientIPIsLoopback returns a predicate that returns true if the address of the client, as returned by
+// extractor.ExtractClientIP, is a loopback address.  See ClientIPIn.
+func ClientIPIsLoopback() Predicate {
+	return describe("ClientIPIsLoopback", ExtractedValueAccepted(extractor.ExtractClientIP(), IPIsLoopback()))
+}
+
+// proxyAwareExtractors rebuild, by the name they are described by, the extractors whose value depends on the proxies
+// they trust.
+var proxyAwareExtractors = map[string]func(...string) extractor.Extractor{
+	"ExtractClientIP": extractor.ExtractClientIP,
+	"ExtractScheme":   extractor.ExtractScheme,
+	"ExtractPort":     extractor.ExtractPort,
+}
+
+// WithTrustedProxies returns a copy of the predicate in which every ExtractedValuePredicate that extracts the address
+// of the client, the scheme or the port, including the ones combined by And, Or, Not and RequestMatches and the ones
+// built by functions like ClientIPInCIDR or SchemeEquals, trusts the proxies rather than
+// extractor.DefaultTrustedProxies, e.g.
+// WithTrustedProxies(ClientIPInCIDR("203.0.113.0/24"), "10.0.0.0/8").  The proxies are IP addresses or CIDR ranges.
+// The copy is a TrustedProxiesPredicate so that it describes the proxies.  WithTrustedProxies panics if a proxy can
+// not be parsed.
+func WithTrustedProxies(p Predicate, trustedProxies ...string) Predicate {
+	extractor.MustIPSet(trustedProxies...)
+	mapped := mapExtractedValuePredicates(p, func(evp ExtractedValuePredicate) ExtractedValuePredicate {
+		if rebuild, ok := proxyAwareExtractors[extractor.Describe(evp.Extractor).Name]; ok {
+			evp.Extractor = rebuild(trustedProxies...)
+		}
+		return evp
+	})
+	return TrustedProxiesPredicate{Predicate: mapped, TrustedProxies: trustedProxies}
+}
+
+// TrustedProxiesPredicate is the Predicate returned by WithTrustedProxies.  Predicate is the copy of the predicate
+// that trusts the proxies.
+type TrustedProxiesPredicate struct {
+	Predicate      Predicate
+	TrustedProxies []string
+}
+
+// Accept calls the wrapped predicate.
+func (tp TrustedProxiesPredicate) Accept(v interface{}) bool {
+	return tp.Predicate.Accept(v)
+}
+
+// AcceptE calls the wrapped predicate.
+func (tp TrustedProxiesPredicate) AcceptE(v interface{}) (bool, error) {
+	return AcceptE(tp.Predicate, v)
+}
+
+// Evaluate evaluates the wrapped predicate.
+func (tp TrustedProxiesPredicate) Evaluate(v interface{}) *Result {
+	return Evaluate(tp.Predicate, v)
+}
+
+// Describe describes the proxies and the wrapped predicate.
+func (tp TrustedProxiesPredicate) Describe() Description {
+	description := Description{Name: "WithTrustedProxies", Children: []Description{extractor.Describe(tp.Predicate)}}
+	for _, proxy := range tp.TrustedProxies {
+		description.Args = append(description.Args, proxy)
+	}
+	return description
+}
+
+// String renders the predicate as a function call, e.g.
+// WithTrustedProxies("10.0.0.0/8", ClientIPInCIDR("203.0.113.0/24")).
+func (tp TrustedProxiesPredicate) String() string {
+	return tp.Describe().String()
+}
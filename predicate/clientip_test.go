@@ -0,0 +1,72 @@
+package predicate_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/netip"
+	"testing"
+)
+
+func TestIPPredicates(t *testing.T) {
+	assert.True(t, IPInCIDR("10.0.0.0/8", "2001:db8::/32").Accept(netip.MustParseAddr("10.1.2.3")))
+	assert.True(t, IPInCIDR("10.0.0.0/8", "2001:db8::/32").Accept("2001:db8::1"))
+	assert.False(t, IPInCIDR("10.0.0.0/8").Accept("11.0.0.1"))
+	assert.True(t, IPIn(extractor.MustIPSet("192.0.2.1")).Accept("::ffff:192.0.2.1"))
+	assert.True(t, IPIsPrivate().Accept("192.168.1.1"))
+	assert.True(t, IPIsPrivate().Accept("fd00::1"))
+	assert.False(t, IPIsPrivate().Accept("8.8.8.8"))
+	assert.True(t, IPIsLoopback().Accept("::1"))
+	assert.False(t, IPIsLoopback().Accept(netip.Addr{}))
+
+	_, err := AcceptE(IPIsLoopback(), "localhost")
+	assert.True(t, errors.Is(err, extractor.ErrParse), "%v", err)
+	_, err = AcceptE(IPIsLoopback(), 127)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+
+	assert.Equal(t, `IPInCIDR("10.0.0.0/8", "::1")`,
+		IPIn(extractor.MustIPSet("10.0.0.0/8", "::1")).(IPPredicate).String())
+	assert.Equal(t, `IPIsPrivate()`, IPIsPrivate().(IPPredicate).String())
+}
+
+func TestClientIPPredicates(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.RemoteAddr = "192.168.1.10:5555"
+
+	assert.True(t, ClientIPInCIDR("192.168.0.0/16").Accept(req))
+	assert.False(t, ClientIPInCIDR("10.0.0.0/8", "2001:db8::/32").Accept(req))
+	assert.True(t, ClientIPIn(extractor.MustIPSet("192.168.1.10")).Accept(req))
+	assert.True(t, ClientIPIsPrivate().Accept(req))
+	assert.False(t, ClientIPIsLoopback().Accept(req))
+
+	accepted, explanation := Explain(ClientIPInCIDR("10.0.0.0/8"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "client ip: expected to be in '10.0.0.0/8', got '192.168.1.10'", explanation)
+	accepted, explanation = Explain(Not(ClientIPIsPrivate()), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "client ip: expected not a private address, got '192.168.1.10'", explanation)
+	assert.Equal(t, `ClientIPIn("192.168.1.10")`,
+		ClientIPIn(extractor.MustIPSet("192.168.1.10")).(DescribedPredicate).String())
+}
+
+func TestWithTrustedProxies(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.RemoteAddr = "10.0.0.1:5555"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	p := And(MethodIs("GET"), Not(ClientIPIsPrivate()), ClientIPInCIDR("203.0.113.0/24"))
+	assert.False(t, p.Accept(req))
+	assert.True(t, WithTrustedProxies(p, "10.0.0.0/8").Accept(req))
+	assert.False(t, WithTrustedProxies(p, "192.168.0.0/16").Accept(req))
+	assert.True(t, WithTrustedProxies(RequestMatches(ClientIPIsLoopback()), "10.0.0.1").Accept(
+		&http.Response{Request: &http.Request{RemoteAddr: "10.0.0.1:80", Header: http.Header{
+			"X-Forwarded-For": {"127.0.0.1"}}}}))
+	assert.Equal(t, `WithTrustedProxies("10.0.0.0/8", ClientIPIsLoopback())`,
+		fmt.Sprint(WithTrustedProxies(ClientIPIsLoopback(), "10.0.0.0/8")))
+	assert.Panics(t, func() { WithTrustedProxies(p, "10.0.0.0/33") })
+}
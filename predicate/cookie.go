@@ -0,0 +1,44 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"regexp"
+)
+
+// CookieEquals returns a predicate that returns true if the cookie named 'name' equals 'value'.  A missing cookie is
+// rejected, even if 'value' is empty.
+func CookieEquals(name, value string) Predicate {
+	return describe("CookieEquals",
+		ExtractedValueAccepted(extractor.ExtractCookie(name), StringEquals(value)),
+		name, value)
+}
+
+// CookieMatches returns a predicate that returns true if the cookie named 'name' matches 'regex'.
+func CookieMatches(name string, regex *regexp.Regexp) Predicate {
+	return describe("CookieMatches",
+		ExtractedValueAccepted(extractor.ExtractCookie(name), StringMatches(regex)),
+		name, regex)
+}
+
+// CookieStartsWith returns a predicate that returns true if the cookie named 'name' starts with 'prefix'.
+func CookieStartsWith(name, prefix string) Predicate {
+	return describe("CookieStartsWith",
+		ExtractedValueAccepted(extractor.ExtractCookie(name), StringStartsWith(prefix)),
+		name, prefix)
+}
+
+// CookieExists returns a predicate that returns true if the request has a cookie named 'name', even if it is empty.
+func CookieExists(name string) Predicate {
+	return describe("CookieExists", ExtractedValueAccepted(extractor.ExtractOptionalCookie(name), IsPresent()), name)
+}
@@ -0,0 +1,31 @@
+package predicate_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestCookiePredicates(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Cookie", "session=abc123; bad cookie; beta=")
+
+	assert.True(t, CookieEquals("session", "abc123").Accept(req))
+	assert.False(t, CookieEquals("session", "abc").Accept(req))
+	assert.True(t, CookieEquals("beta", "").Accept(req))
+	assert.False(t, CookieEquals("other", "").Accept(req))
+	assert.True(t, CookieMatches("session", regexp.MustCompile("^[a-z]+[0-9]+$")).Accept(req))
+	assert.False(t, CookieMatches("session", regexp.MustCompile("^[0-9]+$")).Accept(req))
+	assert.True(t, CookieStartsWith("session", "abc").Accept(req))
+	assert.False(t, CookieStartsWith("session", "123").Accept(req))
+	assert.True(t, CookieExists("beta").Accept(req))
+	assert.False(t, CookieExists("other").Accept(req))
+
+	accepted, explanation := Explain(CookieEquals("other", "x"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "cookie other: value missing: no cookie named other", explanation)
+	assert.Equal(t, `CookieEquals("session", "abc123")`, CookieEquals("session", "abc123").(DescribedPredicate).String())
+}
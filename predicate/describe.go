@@ -0,0 +1,139 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+)
+
+// Description is a structured description of how a Predicate was built.  See extractor.Description.
+type Description = extractor.Description
+
+// Describer is implemented by predicates that can describe how they were built.
+type Describer = extractor.Describer
+
+// DescribedPredicate decorates a Predicate with the name of the function that built it and the arguments that were
+// passed to that function.  The built in predicates that are composed from other predicates, like HeaderEquals, return
+// a DescribedPredicate so that they describe themselves by the name they were called by rather than by their parts.
+type DescribedPredicate struct {
+	Name      string
+	Args      []interface{}
+	Predicate Predicate
+}
+
+// Accept calls the decorated predicate.
+func (dp DescribedPredicate) Accept(v interface{}) bool {
+	return dp.Predicate.Accept(v)
+}
+
+// Evaluate evaluates the decorated predicate.  If the decorated predicate can not evaluate itself, the result is
+// attributed to the DescribedPredicate so that explanations can name it.
+func (dp DescribedPredicate) Evaluate(v interface{}) *Result {
+	result := Evaluate(dp.Predicate, v)
+	if _, ok := dp.Predicate.(Evaluator); !ok {
+		result.Predicate = dp
+	}
+	return result
+}
+
+// Describe returns the name and arguments of the predicate.
+func (dp DescribedPredicate) Describe() Description {
+	return Description{Name: dp.Name, Args: dp.Args}
+}
+
+// String renders the predicate as a function call, e.g. HeaderEquals("X-Id", "1").
+func (dp DescribedPredicate) String() string {
+	return dp.Describe().String()
+}
+
+func describe(name string, p Predicate, args ...interface{}) Predicate {
+	return DescribedPredicate{Name: name, Args: args, Predicate: p}
+}
+
+func describeAll(predicates []Predicate) []Description {
+	descriptions := make([]Description, 0, len(predicates))
+	for _, p := range predicates {
+		descriptions = append(descriptions, extractor.Describe(p))
+	}
+	return descriptions
+}
+
+// Describe describes the predicate and the predicates it combines.
+func (ap AndPredicate) Describe() Description {
+	return Description{Name: "And", Children: describeAll(ap)}
+}
+
+// String renders the predicate as a function call, e.g. And(MethodIs("GET"), PathStartsWith("/api")).
+func (ap AndPredicate) String() string {
+	return ap.Describe().String()
+}
+
+// Describe describes the predicate and the predicates it combines.
+func (op OrPredicate) Describe() Description {
+	return Description{Name: "Or", Children: describeAll(op)}
+}
+
+// String renders the predicate as a function call, e.g. Or(PathEquals("/a"), PathEquals("/b")).
+func (op OrPredicate) String() string {
+	return op.Describe().String()
+}
+
+// Describe describes the predicate and the predicate it negates.
+func (np NotPredicate) Describe() Description {
+	return Description{Name: "Not", Children: []Description{extractor.Describe(np.Predicate)}}
+}
+
+// String renders the predicate as a function call, e.g. Not(MethodIs("GET")).
+func (np NotPredicate) String() string {
+	return np.Describe().String()
+}
+
+// Describe describes the predicate, its extractor and the predicate applied to the extracted value.
+func (evp ExtractedValuePredicate) Describe() Description {
+	return Description{
+		Name:     "ExtractedValueAccepted",
+		Children: []Description{extractor.Describe(evp.Extractor), extractor.Describe(evp.Predicate)},
+	}
+}
+
+// String renders the predicate as a function call, e.g.
+// ExtractedValueAccepted(ExtractHeader("X-Id"), StringEquals("1")).
+func (evp ExtractedValuePredicate) String() string {
+	return evp.Describe().String()
+}
+
+var stringPredicateNames = map[string]string{
+	"equals":      "StringEquals",
+	"contains":    "StringContains",
+	"starts with": "StringStartsWith",
+	"ends with":   "StringEndsWith",
+	"matches":     "StringMatches",
+	"is one of":   "StringIn",
+}
+
+// Describe returns the name of the function that built the predicate and the expected value, or values.
+func (sp StringPredicate) Describe() Description {
+	description := Description{Name: stringPredicateNames[sp.Operator]}
+	if values, ok := sp.Expected.([]string); ok {
+		for _, value := range values {
+			description.Args = append(description.Args, value)
+		}
+	} else {
+		description.Args = []interface{}{sp.Expected}
+	}
+	return description
+}
+
+// String renders the predicate as a function call, e.g. StringEquals("foo").
+func (sp StringPredicate) String() string {
+	return sp.Describe().String()
+}
@@ -0,0 +1,74 @@
+package predicate_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestPredicates_String(t *testing.T) {
+	tests := []struct {
+		Predicate Predicate
+		String    string
+	}{
+		{True(), "True()"},
+		{False(), "False()"},
+		{And(MethodIs("GET"), PathStartsWith("/api")), `And(MethodIs("GET"), PathStartsWith("/api"))`},
+		{Or(PathEquals("/a"), Not(PathMatches(regexp.MustCompile("^/b")))), `Or(PathEquals("/a"), Not(PathMatches(/^/b/)))`},
+		{HeaderEquals("X-Id", "1"), `HeaderEquals("X-Id", "1")`},
+		{HeaderEqualsIgnoreCase("X-Id", "a"), `HeaderEqualsIgnoreCase("X-Id", "a")`},
+		{HeaderContains("X-Id", "a"), `HeaderContains("X-Id", "a")`},
+		{HeaderContainsIgnoreCase("X-Id", "a"), `HeaderContainsIgnoreCase("X-Id", "a")`},
+		{HeaderMatches("X-Id", regexp.MustCompile("[0-9]+")), `HeaderMatches("X-Id", /[0-9]+/)`},
+		{HeaderStartsWith("X-Id", "a"), `HeaderStartsWith("X-Id", "a")`},
+		{QueryParamEquals("q", "1"), `QueryParamEquals("q", "1")`},
+		{QueryParamEqualsIgnoreCase("q", "a"), `QueryParamEqualsIgnoreCase("q", "a")`},
+		{QueryParamContains("q", "a"), `QueryParamContains("q", "a")`},
+		{QueryParamContainsIgnoreCase("q", "a"), `QueryParamContainsIgnoreCase("q", "a")`},
+		{QueryParamMatches("q", regexp.MustCompile("a")), `QueryParamMatches("q", /a/)`},
+		{QueryParamStartsWith("q", "a"), `QueryParamStartsWith("q", "a")`},
+		{RequestURIEquals("/a?b=c"), `RequestURIEquals("/a?b=c")`},
+		{RequestURIMatches(regexp.MustCompile("^/a")), `RequestURIMatches(/^/a/)`},
+		{RequestURIStartsWith("/a"), `RequestURIStartsWith("/a")`},
+		{BodyXPathEquals("/a/b", "c"), `BodyXPathEquals("/a/b", "c")`},
+		{BodyXPathEqualsIgnoreCase("/a/b", "c"), `BodyXPathEqualsIgnoreCase("/a/b", "c")`},
+		{BodyXPathMatches("/a/b", regexp.MustCompile("c")), `BodyXPathMatches("/a/b", /c/)`},
+		{StringEquals("a"), `StringEquals("a")`},
+		{StringContains("a"), `StringContains("a")`},
+		{StringStartsWith("a"), `StringStartsWith("a")`},
+		{StringEndsWith("a"), `StringEndsWith("a")`},
+		{StringMatches(regexp.MustCompile("a")), `StringMatches(/a/)`},
+		{ExtractedValueAccepted(extractor.ExtractHeader("X-Id"), StringEquals("1")),
+			`ExtractedValueAccepted(ExtractHeader("X-Id"), StringEquals("1"))`},
+	}
+	for _, tst := range tests {
+		assert.Equal(t, tst.String, fmt.Sprint(tst.Predicate))
+	}
+}
+
+func TestPredicates_Describe(t *testing.T) {
+	d := extractor.Describe(And(MethodIs("GET"), Not(HeaderEquals("X-Id", "1"))))
+	assert.Equal(t, Description{
+		Name: "And",
+		Children: []Description{
+			{Name: "MethodIs", Args: []interface{}{"GET"}},
+			{Name: "Not", Children: []Description{
+				{Name: "HeaderEquals", Args: []interface{}{"X-Id", "1"}},
+			}},
+		},
+	}, d)
+
+	d = extractor.Describe(ExtractedValueAccepted(extractor.ExtractPath(), PredicateFunc(func(interface{}) bool {
+		return true
+	})))
+	assert.Equal(t, Description{
+		Name: "ExtractedValueAccepted",
+		Children: []Description{
+			{Name: "ExtractPath"},
+			{Name: "predicate.PredicateFunc"},
+		},
+	}, d)
+}
@@ -0,0 +1,175 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+)
+
+// ErrorPolicy decides what an ExtractedValuePredicate does when the value can not be extracted, e.g. because the body
+// is not XML, or when the value can not be tested, e.g. because it is not a string.
+type ErrorPolicy int
+
+const (
+	// RejectOnError rejects the value.  It is the default policy.  Beware that Not(p) accepts the values p rejects
+	// because of an error.
+	RejectOnError ErrorPolicy = iota
+	// AcceptOnError accepts the value.
+	AcceptOnError
+	// PropagateError returns the error from AcceptE.  And, Or and Not stop and return the error as well, so that it
+	// surfaces from the top of the predicate tree, and Accept rejects the value no matter how many Nots wrap the
+	// predicate.
+	PropagateError
+)
+
+var errorPolicyNames = map[ErrorPolicy]string{
+	RejectOnError:  "reject on error",
+	AcceptOnError:  "accept on error",
+	PropagateError: "propagate error",
+}
+
+// String returns the name of the policy, e.g. "reject on error".
+func (ep ErrorPolicy) String() string {
+	if name, ok := errorPolicyNames[ep]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorPolicy(%d)", int(ep))
+}
+
+// ErrorPredicate is implemented by predicates that can report an error instead of accepting or rejecting a value.
+type ErrorPredicate interface {
+	Predicate
+	AcceptE(interface{}) (bool, error)
+}
+
+// AcceptE returns whether the predicate accepts the value or the error that prevented it from deciding.  Predicates
+// that are not ErrorPredicates never return an error.
+func AcceptE(p Predicate, v interface{}) (bool, error) {
+	if ep, ok := p.(ErrorPredicate); ok {
+		return ep.AcceptE(v)
+	}
+	return p.Accept(v), nil
+}
+
+// WithErrorPolicy returns a copy of the predicate in which every ExtractedValuePredicate, including the ones combined
+// by And, Or, Not and RequestMatches and the ones built by functions like HeaderEquals, uses the policy.
+func WithErrorPolicy(p Predicate, policy ErrorPolicy) Predicate {
+	return mapExtractedValuePredicates(p, func(evp ExtractedValuePredicate) ExtractedValuePredicate {
+		evp.OnError = policy
+		return evp
+	})
+}
+
+// mapExtractedValuePredicates returns a copy of the predicate in which every ExtractedValuePredicate, including the
+// ones combined by And, Or, Not and RequestMatches and the ones decorated by a DescribedPredicate or a
+// TrustedProxiesPredicate, is replaced by the one f returns.
+func mapExtractedValuePredicates(p Predicate, f func(ExtractedValuePredicate) ExtractedValuePredicate) Predicate {
+	switch pred := p.(type) {
+	case ExtractedValuePredicate:
+		return f(pred)
+	case DescribedPredicate:
+		pred.Predicate = mapExtractedValuePredicates(pred.Predicate, f)
+		return pred
+	case TrustedProxiesPredicate:
+		pred.Predicate = mapExtractedValuePredicates(pred.Predicate, f)
+		return pred
+	case NotPredicate:
+		return NotPredicate{mapExtractedValuePredicates(pred.Predicate, f)}
+	case RequestPredicate:
+		return RequestPredicate{mapExtractedValuePredicates(pred.Predicate, f)}
+	case AndPredicate:
+		return AndPredicate(mapAllExtractedValuePredicates(pred, f))
+	case OrPredicate:
+		return OrPredicate(mapAllExtractedValuePredicates(pred, f))
+	}
+	return p
+}
+
+func mapAllExtractedValuePredicates(predicates []Predicate,
+	f func(ExtractedValuePredicate) ExtractedValuePredicate) []Predicate {
+	out := make([]Predicate, 0, len(predicates))
+	for _, p := range predicates {
+		out = append(out, mapExtractedValuePredicates(p, f))
+	}
+	return out
+}
+
+// AcceptE returns false as soon as one of the predicates rejects the value or returns an error.
+func (ap AndPredicate) AcceptE(v interface{}) (bool, error) {
+	for _, p := range ap {
+		accepted, err := AcceptE(p, v)
+		if err != nil || !accepted {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// AcceptE returns true as soon as one of the predicates accepts the value, or false as soon as one returns an error.
+func (op OrPredicate) AcceptE(v interface{}) (bool, error) {
+	for _, p := range op {
+		accepted, err := AcceptE(p, v)
+		if err != nil || accepted {
+			return accepted && err == nil, err
+		}
+	}
+	return false, nil
+}
+
+// AcceptE negates the result of the wrapped predicate.  Errors are returned as is.
+func (np NotPredicate) AcceptE(v interface{}) (bool, error) {
+	accepted, err := AcceptE(np.Predicate, v)
+	if err != nil {
+		return false, err
+	}
+	return !accepted, nil
+}
+
+// AcceptE calls the decorated predicate.
+func (dp DescribedPredicate) AcceptE(v interface{}) (bool, error) {
+	return AcceptE(dp.Predicate, v)
+}
+
+// AcceptE extracts the value and tests it using the predicate.  Errors raised by either are handled according to
+// OnError.
+func (evp ExtractedValuePredicate) AcceptE(v interface{}) (bool, error) {
+	value, err := extractor.ExtractE(evp.Extractor, v)
+	accepted := false
+	if err == nil {
+		accepted, err = AcceptE(evp.Predicate, value)
+	}
+	if err != nil {
+		return evp.OnError.handle(err)
+	}
+	return accepted, nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a string.
+func (sp StringPredicate) AcceptE(v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, fmt.Errorf("%w: expected a string, got %T", extractor.ErrWrongType, v)
+	}
+	return sp.Func(s), nil
+}
+
+// handle returns the outcome of a predicate that encountered the error.
+func (ep ErrorPolicy) handle(err error) (bool, error) {
+	switch ep {
+	case AcceptOnError:
+		return true, nil
+	case PropagateError:
+		return false, err
+	}
+	return false, nil
+}
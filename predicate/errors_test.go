@@ -0,0 +1,93 @@
+package predicate_test
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func xmlRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(body))
+	assert.NoError(t, err, "failed to create test request.")
+	return req
+}
+
+func TestErrorPolicy_Reject(t *testing.T) {
+	req := xmlRequest(t, `<order><id></id></order>`)
+	assert.True(t, BodyXPathEquals("/order/id", "").Accept(req))
+	assert.False(t, BodyXPathEquals("/order/missing", "").Accept(req))
+	assert.True(t, Not(BodyXPathEquals("/order/missing", "")).Accept(req))
+
+	assert.False(t, MethodIs("GET").Accept(&http.Response{}))
+	assert.False(t, MethodIs("GET").Accept(nil))
+	assert.False(t, StringEquals("a").Accept(5))
+}
+
+func TestErrorPolicy_Accept(t *testing.T) {
+	req := xmlRequest(t, `<order><id>1</id></order>`)
+	p := WithErrorPolicy(BodyXPathEquals("/order/missing", "1"), AcceptOnError)
+	assert.True(t, p.Accept(req))
+	assert.False(t, Not(p).Accept(req))
+	assert.False(t, WithErrorPolicy(BodyXPathEquals("/order/id", "2"), AcceptOnError).Accept(req))
+}
+
+func TestErrorPolicy_Propagate(t *testing.T) {
+	req := xmlRequest(t, `<order><id>1</id></order>`)
+	missing := WithErrorPolicy(BodyXPathEquals("/order/missing", "1"), PropagateError)
+
+	accepted, err := AcceptE(missing, req)
+	assert.False(t, accepted)
+	assert.True(t, errors.Is(err, extractor.ErrMissing), "%v", err)
+
+	tests := []struct {
+		Predicate Predicate
+		Accepted  bool
+		Error     bool
+	}{
+		{Not(missing), false, true},
+		{Not(Not(missing)), false, true},
+		{And(True(), missing), false, true},
+		{And(False(), missing), false, false},
+		{Or(True(), missing), true, false},
+		{Or(False(), missing), false, true},
+		{Or(missing, True()), false, true},
+	}
+	for _, tst := range tests {
+		accepted, err := AcceptE(tst.Predicate, req)
+		assert.Equal(t, tst.Accepted, accepted, "%v", tst.Predicate)
+		assert.Equal(t, tst.Error, err != nil, "%v: %v", tst.Predicate, err)
+		assert.Equal(t, tst.Accepted, tst.Predicate.Accept(req), "%v", tst.Predicate)
+		result := Evaluate(tst.Predicate, req)
+		assert.Equal(t, tst.Accepted, result.Accepted, "%v", tst.Predicate)
+		assert.Equal(t, tst.Error, result.Err != nil, "%v", tst.Predicate)
+	}
+
+	accepted, err = AcceptE(WithErrorPolicy(And(MethodIs("POST"), Not(HeaderEquals("X-Id", "1"))), PropagateError), req)
+	assert.True(t, accepted)
+	assert.NoError(t, err)
+}
+
+func TestErrorPolicy_Explain(t *testing.T) {
+	req := xmlRequest(t, `<order><id>1</id></order>`)
+	accepted, explanation := Explain(BodyXPathEquals("/order/missing", "1"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "xpath /order/missing: value missing: xpath /order/missing selected nothing", explanation)
+
+	accepted, explanation = Explain(Not(WithErrorPolicy(BodyXPathEquals("/order/missing", "1"), PropagateError)), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "xpath /order/missing: value missing: xpath /order/missing selected nothing", explanation)
+
+	accepted, explanation = Explain(StringEquals("a"), 5)
+	assert.False(t, accepted)
+	assert.Equal(t, "wrong type: expected a string, got int", explanation)
+}
+
+func TestErrorPolicy_String(t *testing.T) {
+	assert.Equal(t, "reject on error", RejectOnError.String())
+	assert.Equal(t, "propagate error", PropagateError.String())
+	assert.Equal(t, "ErrorPolicy(7)", ErrorPolicy(7).String())
+}
@@ -0,0 +1,382 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"strings"
+)
+
+// Result is the outcome of evaluating a Predicate against a value.  Results form a tree that mirrors the predicate
+// tree: the results of the predicates combined by And, Or and Not are found in Children.
+type Result struct {
+	// Predicate is the predicate that was evaluated.
+	Predicate Predicate
+	// Accepted is the value returned by the predicate's Accept method.
+	Accepted bool
+	// Label names the value that was tested, e.g. "header Content-Type".  It is only set by ExtractedValueAccepted.
+	Label string
+	// Value is the value that was tested.
+	Value interface{}
+	// Operator and Expected describe the comparison made by a leaf predicate, e.g. "equals" and "application/json".
+	Operator string
+	Expected interface{}
+	// Children holds the results of the nested predicates.
+	Children []*Result
+	// Err is the error that prevented the predicate from deciding.  For an ExtractedValuePredicate, it is recorded
+	// even if the predicate's error policy accepted or rejected the value.
+	Err error
+}
+
+// Evaluator is implemented by predicates that can report why they accepted or rejected a value.
+type Evaluator interface {
+	Evaluate(interface{}) *Result
+}
+
+// Evaluate evaluates the predicate against the value and returns a tree of results describing the outcome of every
+// node of the predicate.  Unlike Accept, And and Or evaluate all of their predicates so that every reason for a
+// rejection is reported.  Predicates that do not implement Evaluator are reported as leaves.
+func Evaluate(p Predicate, v interface{}) *Result {
+	if e, ok := p.(Evaluator); ok {
+		return e.Evaluate(v)
+	}
+	accepted, err := AcceptE(p, v)
+	return &Result{Predicate: p, Accepted: accepted, Value: v, Err: err}
+}
+
+// Explain evaluates the predicate against the value and returns whether it was accepted along with an explanation
+// of why it was rejected.  The explanation is empty if the value was accepted.
+func Explain(p Predicate, v interface{}) (bool, string) {
+	result := Evaluate(p, v)
+	return result.Accepted, result.Explanation()
+}
+
+// Evaluate evaluates all of the predicates and accepts the value if all of them accept it.  Like AcceptE, the result
+// holds the error propagated by a predicate unless a predicate before it rejected the value.
+func (ap AndPredicate) Evaluate(v interface{}) *Result {
+	result := &Result{Predicate: ap, Accepted: true, Value: v}
+	decided := false
+	for _, p := range ap {
+		child := Evaluate(p, v)
+		if err := child.propagated(); err != nil && !decided {
+			result.Accepted, result.Err, decided = false, err, true
+		} else if !child.Accepted {
+			result.Accepted, decided = false, true
+		}
+		result.Children = append(result.Children, child)
+	}
+	return result
+}
+
+// Evaluate evaluates all of the predicates and accepts the value if any of them accept it.  Like AcceptE, the result
+// holds the error propagated by a predicate unless a predicate before it accepted the value.
+func (op OrPredicate) Evaluate(v interface{}) *Result {
+	result := &Result{Predicate: op, Accepted: false, Value: v}
+	decided := false
+	for _, p := range op {
+		child := Evaluate(p, v)
+		if err := child.propagated(); err != nil && !decided {
+			result.Err, decided = err, true
+		} else if child.Accepted && !decided {
+			result.Accepted, decided = true, true
+		}
+		result.Children = append(result.Children, child)
+	}
+	return result
+}
+
+// Evaluate evaluates the wrapped predicate and negates the result.  A propagated error is not negated.
+func (np NotPredicate) Evaluate(v interface{}) *Result {
+	child := Evaluate(np.Predicate, v)
+	result := &Result{Predicate: np, Accepted: !child.Accepted, Value: v, Children: []*Result{child}}
+	if err := child.propagated(); err != nil {
+		result.Accepted, result.Err = false, err
+	}
+	return result
+}
+
+// Evaluate extracts the value and evaluates the wrapped predicate against it.  If the value can not be extracted, the
+// result has no children.
+func (evp ExtractedValuePredicate) Evaluate(v interface{}) *Result {
+	result := &Result{Predicate: evp, Label: extractor.Label(evp.Extractor)}
+	result.Value, result.Err = extractor.ExtractE(evp.Extractor, v)
+	if result.Err == nil {
+		child := Evaluate(evp.Predicate, result.Value)
+		result.Accepted, result.Err = child.Accepted, child.Err
+		result.Children = []*Result{child}
+	}
+	if result.Err != nil {
+		result.Accepted, _ = evp.OnError.handle(result.Err)
+	}
+	return result
+}
+
+// Evaluate compares the value and records the comparison made.
+func (sp StringPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := sp.AcceptE(v)
+	return &Result{Predicate: sp, Accepted: accepted, Value: v, Operator: sp.Operator, Expected: sp.Expected, Err: err}
+}
+
+// propagated returns the error the result passes on to its parent.  The errors handled by the policy of an
+// ExtractedValuePredicate are not passed on.
+func (r *Result) propagated() error {
+	if evp, ok := r.Predicate.(ExtractedValuePredicate); ok && evp.OnError != PropagateError {
+		return nil
+	}
+	return r.Err
+}
+
+// Explanation returns a human readable explanation of why the value was rejected, one line per failed comparison,
+// e.g. "header Content-Type: expected 'application/json', got 'text/plain'".  It is empty if the value was accepted.
+func (r *Result) Explanation() string {
+	return strings.Join(r.reasons(false, nil), "\n")
+}
+
+// reasons collects the explanations of the leaves that caused this result to fail.  A result fails when it accepted
+// the value and negated is true, or when it rejected the value and negated is false.
+func (r *Result) reasons(negated bool, out []string) []string {
+	if r.propagated() != nil {
+		return r.errorReasons(out)
+	}
+	if r.Accepted != negated {
+		return out
+	}
+	switch r.Predicate.(type) {
+	case NotPredicate:
+		return r.Children[0].reasons(!negated, out)
+	case AndPredicate, OrPredicate, RequestPredicate:
+		for _, child := range r.Children {
+			out = child.reasons(negated, out)
+		}
+		return out
+	case ExtractedValuePredicate:
+		if r.Err != nil {
+			return append(out, r.Label+": "+r.Err.Error())
+		}
+		child := r.Children[0]
+		if len(child.Children) > 0 {
+			for _, reason := range child.reasons(negated, nil) {
+				out = append(out, r.Label+": "+reason)
+			}
+			return out
+		}
+		return append(out, r.Label+": "+child.expectation(negated))
+	}
+	return append(out, r.expectation(negated))
+}
+
+// errorReasons collects the explanations of the errors propagated to this result.
+func (r *Result) errorReasons(out []string) []string {
+	switch r.Predicate.(type) {
+	case AndPredicate, OrPredicate, NotPredicate, RequestPredicate:
+		for _, child := range r.Children {
+			if child.propagated() == r.Err {
+				return child.errorReasons(out)
+			}
+		}
+	case ExtractedValuePredicate:
+		return append(out, r.Label+": "+r.Err.Error())
+	}
+	return append(out, r.Err.Error())
+}
+
+// expectation describes what a leaf result expected the value to be and what it got.
+func (r *Result) expectation(negated bool) string {
+	if r.Operator == "" {
+		verdict := "rejected"
+		if negated {
+			verdict = "accepted"
+		}
+		return fmt.Sprintf("%s %s the value", name(r.Predicate), verdict)
+	}
+	not := ""
+	if negated {
+		not = "not "
+	}
+	var expected string
+	switch r.Operator {
+	case "equals":
+		if negated {
+			expected = "expected anything but " + quote(r.Expected)
+		} else {
+			expected = "expected " + quote(r.Expected)
+		}
+	case "equals ignoring case":
+		expected = "expected " + not + quote(r.Expected) + " ignoring case"
+	case "contains":
+		expected = "expected " + not + "to contain " + quote(r.Expected)
+	case "starts with":
+		expected = "expected " + not + "to start with " + quote(r.Expected)
+	case "ends with":
+		expected = "expected " + not + "to end with " + quote(r.Expected)
+	case "matches":
+		expected = "expected " + not + "to match " + quote(r.Expected)
+	case "matches template":
+		expected = "expected " + not + "to match template " + quote(r.Expected)
+	case "is present", "is absent":
+		if (r.Operator == "is present") != negated {
+			expected = "expected a value"
+		} else {
+			expected = "expected no value"
+		}
+	case "any value":
+		expected = "expected " + not + "any value to be accepted by " + name(r.Expected.(Predicate))
+	case "all values":
+		expected = "expected " + not + "all values to be accepted by " + name(r.Expected.(Predicate))
+	case "value count":
+		expected = fmt.Sprintf("expected %s%v values", not, r.Expected)
+	case "contains all":
+		expected = fmt.Sprintf("expected %sto contain all of %q", not, r.Expected)
+	case "in":
+		expected = "expected " + not + "to be in " + quote(r.Expected)
+	case "is private":
+		expected = "expected " + not + "a private address"
+	case "is loopback":
+		expected = "expected " + not + "a loopback address"
+	case "is media type":
+		expected = "expected " + not + "to be included in " + quote(r.Expected)
+	case "includes media type", "includes language", "includes encoding":
+		expected = "expected " + not + "to accept " + quote(r.Expected)
+	case "is not expired":
+		if negated {
+			expected = "expected a token that has expired at " + quote(r.Expected)
+		} else {
+			expected = "expected a token that has not expired at " + quote(r.Expected)
+		}
+	case "between":
+		if bounds, ok := r.Expected.([]interface{}); ok && len(bounds) == 2 {
+			expected = "expected " + not + "between " + quote(bounds[0]) + " and " + quote(bounds[1])
+		} else {
+			expected = "expected " + not + "between " + quote(r.Expected)
+		}
+	case "equals number":
+		expected = "expected " + not + "to equal " + quote(r.Expected)
+	case "is one of", "is host in":
+		expected = fmt.Sprintf("expected %sone of %q", not, r.Expected)
+	case "is not empty":
+		if negated {
+			expected = "expected no value"
+		} else {
+			expected = "expected a value"
+		}
+	default:
+		expected = fmt.Sprintf("expected %s%s %s", not, r.Operator, quote(r.Expected))
+	}
+	return expected + ", got " + quote(r.Value)
+}
+
+// Closest evaluates the predicates against the value and returns the index of the one that came closest to accepting
+// it, i.e. the one for which the largest fraction of comparisons passed, along with its result.  Ties go to the
+// predicate that comes first.  Closest returns -1 and nil if there are no predicates.
+func Closest(predicates []Predicate, v interface{}) (int, *Result) {
+	best, bestResult, bestScore := -1, (*Result)(nil), -1.0
+	for i, p := range predicates {
+		result := Evaluate(p, v)
+		passed, total := result.Score()
+		s := 0.0
+		if total > 0 {
+			s = float64(passed) / float64(total)
+		}
+		if s > bestScore {
+			best, bestResult, bestScore = i, result, s
+		}
+	}
+	return best, bestResult
+}
+
+// Score counts the comparisons made by the leaf predicates of the result and how many of them passed.  A comparison
+// under a Not passes when it rejects the value.  The fraction that passed tells how close a predicate came to
+// accepting a value.
+func (r *Result) Score() (passed, total int) {
+	return r.score(false)
+}
+
+func (r *Result) score(negated bool) (passed, total int) {
+	switch r.Predicate.(type) {
+	case NotPredicate:
+		return r.Children[0].score(!negated)
+	case AndPredicate, OrPredicate, RequestPredicate:
+		for _, child := range r.Children {
+			p, t := child.score(negated)
+			passed += p
+			total += t
+		}
+		return passed, total
+	}
+	if r.Accepted != negated {
+		return 1, 1
+	}
+	return 0, 1
+}
+
+// String renders the result tree with one node per line, indented to show the structure of the predicate.
+func (r *Result) String() string {
+	sb := &strings.Builder{}
+	r.render(sb, 0)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func (r *Result) render(sb *strings.Builder, depth int) {
+	status := "FAIL"
+	if r.Accepted {
+		status = "PASS"
+	}
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString("[" + status + "] ")
+	switch r.Predicate.(type) {
+	case AndPredicate:
+		sb.WriteString("and")
+	case OrPredicate:
+		sb.WriteString("or")
+	case NotPredicate:
+		sb.WriteString("not")
+	case ExtractedValuePredicate:
+		if r.Err != nil {
+			sb.WriteString(r.Label + ": " + r.Err.Error())
+		} else {
+			sb.WriteString(r.Label + " = " + quote(r.Value))
+		}
+	default:
+		if r.Operator != "" && r.Expected == nil {
+			sb.WriteString(r.Operator)
+		} else if r.Operator != "" {
+			sb.WriteString(r.Operator + " " + quote(r.Expected))
+		} else {
+			sb.WriteString(name(r.Predicate))
+		}
+	}
+	sb.WriteString("\n")
+	for _, child := range r.Children {
+		child.render(sb, depth+1)
+	}
+}
+
+// name returns the string representation of the predicate if it has one.
+func name(p Predicate) string {
+	if s, ok := p.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return "predicate"
+}
+
+// quote formats a value for inclusion in an explanation.
+func quote(v interface{}) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return "'" + s.String() + "'"
+	}
+	switch v.(type) {
+	case string:
+		return fmt.Sprintf("'%s'", v)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,22 @@
+package predicate_test
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+)
+
+func ExampleExplain() {
+	req, _ := http.NewRequest("POST", "http://foo.com/api/orders", nil)
+	req.Header.Add("Content-Type", "text/plain")
+
+	accepted, explanation := predicate.Explain(predicate.And(
+		predicate.MethodIs("POST"),
+		predicate.PathStartsWith("/api/"),
+		predicate.HeaderEquals("Content-Type", "application/json"),
+	), req)
+	fmt.Printf("%v\n%s\n", accepted, explanation)
+	// Output:
+	// false
+	// header Content-Type: expected 'application/json', got 'text/plain'
+}
@@ -0,0 +1,123 @@
+package predicate_test
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestEvaluate_And(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("Content-Type", "text/plain")
+
+	result := Evaluate(And(MethodIs("GET"), HeaderEquals("Content-Type", "application/json")), req)
+	assert.False(t, result.Accepted)
+	if assert.Len(t, result.Children, 2) {
+		assert.True(t, result.Children[0].Accepted)
+		assert.False(t, result.Children[1].Accepted)
+		assert.Equal(t, "header Content-Type", result.Children[1].Label)
+		assert.Equal(t, "text/plain", result.Children[1].Value)
+		if assert.Len(t, result.Children[1].Children, 1) {
+			assert.Equal(t, "equals", result.Children[1].Children[0].Operator)
+			assert.Equal(t, "application/json", result.Children[1].Children[0].Expected)
+		}
+	}
+	assert.Equal(t, "header Content-Type: expected 'application/json', got 'text/plain'", result.Explanation())
+}
+
+func TestEvaluate_Or(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	result := Evaluate(Or(PathStartsWith("/v2/"), QueryParamMatches("q", regexp.MustCompile("^[a-z]+$"))), req)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, "path: expected to start with '/v2/', got '/api/foo'\n"+
+		"query parameter q: expected to match '^[a-z]+$', got '5'", result.Explanation())
+
+	result = Evaluate(Or(PathStartsWith("/v2/"), PathStartsWith("/api/")), req)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, "", result.Explanation())
+}
+
+func TestEvaluate_Not(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	result := Evaluate(Not(And(MethodIs("GET"), PathEquals("/api/foo"))), req)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, "upper case method: expected anything but 'GET', got 'GET'\n"+
+		"path: expected anything but '/api/foo', got '/api/foo'", result.Explanation())
+
+	result = Evaluate(Not(Not(RequestURIStartsWith("/v2"))), req)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, "request URI: expected to start with '/v2', got '/api/foo?q=5'", result.Explanation())
+}
+
+func TestResult_Score(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	passed, total := Evaluate(And(MethodIs("GET"), PathEquals("/api/bar"), Not(QueryParamExists("x"))), req).Score()
+	assert.Equal(t, 2, passed)
+	assert.Equal(t, 3, total)
+	passed, total = Evaluate(Not(Or(MethodIs("GET"), MethodIs("PUT"))), req).Score()
+	assert.Equal(t, 1, passed)
+	assert.Equal(t, 2, total)
+}
+
+func TestClosest(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	i, result := Closest([]Predicate{
+		And(MethodIs("POST"), PathEquals("/api/foo")),
+		And(MethodIs("GET"), PathEquals("/api/foo"), QueryParamEquals("q", "6")),
+		And(MethodIs("GET"), PathEquals("/api/bar")),
+	}, req)
+	assert.Equal(t, 1, i)
+	assert.Equal(t, "query parameter q: expected '6', got '5'", result.Explanation())
+	i, result = Closest(nil, req)
+	assert.Equal(t, -1, i)
+	assert.Nil(t, result)
+}
+
+func TestEvaluate_Opaque(t *testing.T) {
+	result := Evaluate(And(True(), False()), nil)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, "False() rejected the value", result.Explanation())
+
+	result = Evaluate(ExtractedValueAccepted(extractor.IdentityExtractor(), PredicateFunc(func(interface{}) bool {
+		return false
+	})), nil)
+	assert.Equal(t, "value: predicate rejected the value", result.Explanation())
+}
+
+func TestResult_String(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	result := Evaluate(And(MethodIs("GET"), Not(PathEquals("/api/foo"))), req)
+	assert.Equal(t, `[FAIL] and
+  [PASS] upper case method = 'GET'
+    [PASS] equals 'GET'
+  [FAIL] not
+    [PASS] path = '/api/foo'
+      [PASS] equals '/api/foo'`, result.String())
+}
+
+func TestExplain(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/api/foo?q=5", nil)
+	assert.NoError(t, err, "failed to create test request.")
+
+	accepted, explanation := Explain(QueryParamEquals("q", "5"), req)
+	assert.True(t, accepted)
+	assert.Equal(t, "", explanation)
+
+	accepted, explanation = Explain(QueryParamEquals("q", "6"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "query parameter q: expected '6', got '5'", explanation)
+}
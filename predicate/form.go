@@ -0,0 +1,100 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"regexp"
+	"strings"
+)
+
+// FormFieldEquals returns a Predicate that takes a request, parses its body as a urlencoded or multipart form,
+// extracts the field specified and returns true if it equals the value provided.  A body that is not a form or a
+// form without the field is rejected.
+func FormFieldEquals(name, value string) Predicate {
+	return describe("FormFieldEquals",
+		ExtractedValueAccepted(extractor.ExtractFormField(name), StringEquals(value)),
+		name, value)
+}
+
+// FormFieldEqualsIgnoreCase returns a Predicate that takes a request, extracts the form field specified and returns
+// true if it equals the value provided, ignoring case.
+func FormFieldEqualsIgnoreCase(name, value string) Predicate {
+	return describe("FormFieldEqualsIgnoreCase",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractFormField(name)),
+			StringEquals(strings.ToUpper(value))),
+		name, value)
+}
+
+// FormFieldContains returns a Predicate that takes a request, extracts the form field specified and returns true if
+// it contains the value provided.
+func FormFieldContains(name, value string) Predicate {
+	return describe("FormFieldContains",
+		ExtractedValueAccepted(extractor.ExtractFormField(name), StringContains(value)),
+		name, value)
+}
+
+// FormFieldMatches returns a Predicate that takes a request, extracts the form field specified and returns true if
+// the value matches the pattern provided.
+func FormFieldMatches(name string, pattern *regexp.Regexp) Predicate {
+	return describe("FormFieldMatches",
+		ExtractedValueAccepted(extractor.ExtractFormField(name), StringMatches(pattern)),
+		name, pattern)
+}
+
+// FormFieldStartsWith returns a Predicate that takes a request, extracts the form field specified and returns true if
+// the value starts with the prefix provided.
+func FormFieldStartsWith(name, prefix string) Predicate {
+	return describe("FormFieldStartsWith",
+		ExtractedValueAccepted(extractor.ExtractFormField(name), StringStartsWith(prefix)),
+		name, prefix)
+}
+
+// FormFieldExists returns a predicate that returns true if the request's body is a form with a field named 'name',
+// even if it is empty.
+func FormFieldExists(name string) Predicate {
+	return describe("FormFieldExists",
+		ExtractedValueAccepted(extractor.ExtractOptionalFormField(name), IsPresent()),
+		name)
+}
+
+// MultipartFileNameEquals returns a Predicate that takes a request, parses its body as a multipart form and returns
+// true if the file name of the part holding the field specified equals the value provided.
+func MultipartFileNameEquals(name, fileName string) Predicate {
+	return describe("MultipartFileNameEquals",
+		ExtractedValueAccepted(extractor.ExtractMultipartFileName(name), StringEquals(fileName)),
+		name, fileName)
+}
+
+// MultipartFileNameMatches returns a Predicate that takes a request, parses its body as a multipart form and returns
+// true if the file name of the part holding the field specified matches the pattern provided.
+func MultipartFileNameMatches(name string, pattern *regexp.Regexp) Predicate {
+	return describe("MultipartFileNameMatches",
+		ExtractedValueAccepted(extractor.ExtractMultipartFileName(name), StringMatches(pattern)),
+		name, pattern)
+}
+
+// MultipartContentTypeEquals returns a Predicate that takes a request, parses its body as a multipart form and
+// returns true if the Content-Type of the part holding the field specified equals the value provided.
+func MultipartContentTypeEquals(name, contentType string) Predicate {
+	return describe("MultipartContentTypeEquals",
+		ExtractedValueAccepted(extractor.ExtractMultipartContentType(name), StringEquals(contentType)),
+		name, contentType)
+}
+
+// MultipartContentTypeMatches returns a Predicate that takes a request, parses its body as a multipart form and
+// returns true if the Content-Type of the part holding the field specified matches the pattern provided.
+func MultipartContentTypeMatches(name string, pattern *regexp.Regexp) Predicate {
+	return describe("MultipartContentTypeMatches",
+		ExtractedValueAccepted(extractor.ExtractMultipartContentType(name), StringMatches(pattern)),
+		name, pattern)
+}
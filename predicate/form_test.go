@@ -0,0 +1,46 @@
+package predicate_test
+
+import (
+	"bytes"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFormFieldPredicates(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/login", strings.NewReader("user=Ann&remember="))
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	assert.True(t, FormFieldEquals("user", "Ann").Accept(req))
+	assert.False(t, FormFieldEquals("user", "ann").Accept(req))
+	assert.True(t, FormFieldEqualsIgnoreCase("user", "ann").Accept(req))
+	assert.True(t, FormFieldContains("user", "nn").Accept(req))
+	assert.True(t, FormFieldMatches("user", regexp.MustCompile("^[A-Z]")).Accept(req))
+	assert.True(t, FormFieldStartsWith("user", "An").Accept(req))
+	assert.True(t, FormFieldExists("remember").Accept(req))
+	assert.False(t, FormFieldExists("other").Accept(req))
+	assert.False(t, FormFieldEquals("other", "").Accept(req))
+}
+
+func TestMultipartPredicates(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	part, _ := w.CreateFormFile("file", "report.pdf")
+	part.Write([]byte("%PDF-1.4"))
+	w.Close()
+	req, err := http.NewRequest("POST", "http://foo.com/upload", buf)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	assert.True(t, MultipartFileNameEquals("file", "report.pdf").Accept(req))
+	assert.True(t, MultipartFileNameMatches("file", regexp.MustCompile(`\.pdf$`)).Accept(req))
+	assert.False(t, MultipartFileNameMatches("file", regexp.MustCompile(`\.txt$`)).Accept(req))
+	assert.True(t, MultipartContentTypeEquals("file", "application/octet-stream").Accept(req))
+	assert.True(t, MultipartContentTypeMatches("file", regexp.MustCompile("^application/")).Accept(req))
+	assert.False(t, MultipartFileNameEquals("other", "report.pdf").Accept(req))
+}
@@ -19,32 +19,54 @@ import (
 
 // HeaderMatches returns a predicate that returns true if the header named 'name' matches 'regex'
 func HeaderMatches(name string, regex *regexp.Regexp) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractHeader(name), StringMatches(regex))
+	return describe("HeaderMatches",
+		ExtractedValueAccepted(extractor.ExtractHeader(name), StringMatches(regex)),
+		name, regex)
 }
 
 // HeaderEquals returns a predicate that returns true if the header named 'name' equals 'value'
 func HeaderEquals(name string, value string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractHeader(name), StringEquals(value))
+	return describe("HeaderEquals",
+		ExtractedValueAccepted(extractor.ExtractHeader(name), StringEquals(value)),
+		name, value)
 }
 
 // HeaderEqualsIgnoreCase returns a predicate that returns true if the header named 'name' equals 'value', ignoring
 // case.
 func HeaderEqualsIgnoreCase(name string, path string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractHeader(name)), StringEquals(strings.ToUpper(path)))
+	return describe("HeaderEqualsIgnoreCase",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractHeader(name)), StringEquals(strings.ToUpper(path))),
+		name, path)
 }
 
 // HeaderContains returns a predicate that returns true if the header named 'name' contains 'value'.
 func HeaderContains(name string, path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractHeader(name), StringContains(path))
+	return describe("HeaderContains",
+		ExtractedValueAccepted(extractor.ExtractHeader(name), StringContains(path)),
+		name, path)
 }
 
 // HeaderContainsIgnoreCase returns a predicate that returns true if the header named 'name' contains 'value', ignoring
 // case.
 func HeaderContainsIgnoreCase(name string, path string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractHeader(name)), StringContains(strings.ToUpper(path)))
+	return describe("HeaderContainsIgnoreCase",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractHeader(name)), StringContains(strings.ToUpper(path))),
+		name, path)
 }
 
 // HeaderStartsWith returns a predicate that returns true if the header named 'name' starts with 'value'.
 func HeaderStartsWith(name string, path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractHeader(name), StringStartsWith(path))
+	return describe("HeaderStartsWith",
+		ExtractedValueAccepted(extractor.ExtractHeader(name), StringStartsWith(path)),
+		name, path)
+}
+
+// HeaderExists returns a predicate that returns true if the request has a header named 'name', even if it is empty.
+func HeaderExists(name string) Predicate {
+	return describe("HeaderExists", ExtractedValueAccepted(extractor.ExtractOptionalHeader(name), IsPresent()), name)
+}
+
+// HeaderAbsent returns a predicate that returns true if the request has no header named 'name'.
+func HeaderAbsent(name string) Predicate {
+	return describe("HeaderAbsent", ExtractedValueAccepted(extractor.ExtractOptionalHeader(name), IsAbsent()), name)
 }
@@ -74,3 +74,16 @@ func ExampleHeaderStartsWith() {
 	// true
 	// false
 }
+
+func ExampleHeaderExists() {
+	req, _ := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	req.Header["X-Flag"] = []string{""}
+
+	fmt.Printf("%v\n", predicate.HeaderExists("X-Flag").Accept(req))
+	fmt.Printf("%v\n", predicate.HeaderEquals("X-Other", "").Accept(req))
+	fmt.Printf("%v\n", predicate.HeaderExists("X-Other").Accept(req))
+	// Output:
+	// true
+	// true
+	// false
+}
@@ -69,3 +69,23 @@ func TestRequestKeyStringMatches(t *testing.T) {
 	assert.False(t, StringMatches(regexp.MustCompile("\\d+")).Accept(key))
 	assert.True(t, StringMatches(regexp.MustCompile("[a-z]+")).Accept(key))
 }
+
+func TestHeaderExists(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header["X-Flag"] = []string{""}
+
+	assert.True(t, HeaderExists("X-Flag").Accept(req), "expected true.")
+	assert.True(t, HeaderExists("x-flag").Accept(req), "expected true.")
+	assert.False(t, HeaderExists("X-Other").Accept(req), "expected false.")
+	assert.True(t, HeaderExists("Host").Accept(req), "expected true.")
+	assert.False(t, HeaderAbsent("X-Flag").Accept(req), "expected false.")
+	assert.True(t, HeaderAbsent("X-Other").Accept(req), "expected true.")
+
+	accepted, explanation := Explain(HeaderExists("X-Other"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "header X-Other: expected a value, got '<absent>'", explanation)
+	accepted, explanation = Explain(HeaderAbsent("X-Flag"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "header X-Flag: expected no value, got ''", explanation)
+}
@@ -0,0 +1,179 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"encoding/json"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// JSONPredicate is the Predicate returned by the JSON* functions.  It tests a value decoded from JSON, i.e. a float64,
+// string, bool, nil, []interface{} or map[string]interface{}, using Operator, which is one of "equals", "equals
+// ignoring case", "matches", "contains" or "is not empty".
+type JSONPredicate struct {
+	Operator string
+	Expected interface{}
+	Func     func(interface{}) bool
+}
+
+// Accept returns true if the value satisfies the test.
+func (jp JSONPredicate) Accept(v interface{}) bool {
+	return jp.Func(v)
+}
+
+// Evaluate tests the value and records the test made.
+func (jp JSONPredicate) Evaluate(v interface{}) *Result {
+	return &Result{Predicate: jp, Accepted: jp.Accept(v), Value: v, Operator: jp.Operator, Expected: jp.Expected}
+}
+
+var jsonPredicateNames = map[string]string{
+	"equals":               "JSONEquals",
+	"equals ignoring case": "JSONEqualsIgnoreCase",
+	"matches":              "JSONMatches",
+	"contains":             "JSONContains",
+	"is not empty":         "JSONNotEmpty",
+}
+
+// Describe returns the name of the function that built the predicate and the expected value.
+func (jp JSONPredicate) Describe() Description {
+	d := Description{Name: jsonPredicateNames[jp.Operator]}
+	if jp.Operator != "is not empty" {
+		d.Args = []interface{}{jp.Expected}
+	}
+	return d
+}
+
+// String renders the predicate as a function call, e.g. JSONEquals(5).
+func (jp JSONPredicate) String() string {
+	return jp.Describe().String()
+}
+
+// normalizeJSON converts a Go value to the form encoding/json decodes it into so that, for instance, an int and the
+// float64 decoded from the same number compare as equal.  Values that can not be encoded are returned unchanged.
+func normalizeJSON(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+// JSONEquals returns a predicate that returns true if the value passed is equal to 'value' once 'value' has been
+// converted to the types used by encoding/json.  Numbers of any type, strings, bools, nil, slices, maps and structs
+// can be compared with the values decoded from JSON.
+func JSONEquals(value interface{}) Predicate {
+	expected := normalizeJSON(value)
+	return JSONPredicate{"equals", value, func(v interface{}) bool {
+		return reflect.DeepEqual(expected, v)
+	}}
+}
+
+// JSONEqualsIgnoreCase returns a predicate that returns true if the value passed is a string that equals 'value',
+// ignoring case.
+func JSONEqualsIgnoreCase(value string) Predicate {
+	return JSONPredicate{"equals ignoring case", value, func(v interface{}) bool {
+		s, ok := v.(string)
+		return ok && strings.EqualFold(s, value)
+	}}
+}
+
+// JSONMatches returns a predicate that returns true if the regex matches the value passed.  Strings are matched as is
+// while any other value is matched against its JSON encoding.
+func JSONMatches(regex *regexp.Regexp) Predicate {
+	return JSONPredicate{"matches", regex, func(v interface{}) bool {
+		if s, ok := v.(string); ok {
+			return regex.MatchString(s)
+		}
+		data, err := json.Marshal(v)
+		return err == nil && regex.Match(data)
+	}}
+}
+
+// JSONContains returns a predicate that returns true if the value passed is an array with an element that equals
+// 'value', compared as by JSONEquals.
+func JSONContains(value interface{}) Predicate {
+	expected := normalizeJSON(value)
+	return JSONPredicate{"contains", value, func(v interface{}) bool {
+		array, ok := v.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, element := range array {
+			if reflect.DeepEqual(expected, element) {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// JSONNotEmpty returns a predicate that returns true if the value passed is an array with at least one element.
+func JSONNotEmpty() Predicate {
+	return JSONPredicate{"is not empty", nil, func(v interface{}) bool {
+		array, ok := v.([]interface{})
+		return ok && len(array) > 0
+	}}
+}
+
+// BodyJSONPathEquals checks to see if the value selected by the JSONPath expression equals 'value'.  See JSONEquals
+// for how the values are compared.
+func BodyJSONPathEquals(path string, value interface{}) Predicate {
+	return describe("BodyJSONPathEquals",
+		ExtractedValueAccepted(extractor.ExtractJSONPath(path), JSONEquals(value)),
+		path, value)
+}
+
+// BodyJSONPathEqualsIgnoreCase is similar to BodyJSONPathEquals but only matches strings and ignores case when
+// comparing them.
+func BodyJSONPathEqualsIgnoreCase(path, value string) Predicate {
+	return describe("BodyJSONPathEqualsIgnoreCase",
+		ExtractedValueAccepted(extractor.ExtractJSONPath(path), JSONEqualsIgnoreCase(value)),
+		path, value)
+}
+
+// BodyJSONPathMatches checks to see if the value selected by the JSONPath expression matches the regular expression
+// given in the 'pattern' parameter.
+func BodyJSONPathMatches(path string, pattern *regexp.Regexp) Predicate {
+	return describe("BodyJSONPathMatches",
+		ExtractedValueAccepted(extractor.ExtractJSONPath(path), JSONMatches(pattern)),
+		path, pattern)
+}
+
+// BodyJSONPathContains checks to see if 'value' is a member of the array selected by the JSONPath expression or, if
+// the expression can select more than one value, e.g. "$.items[*].id", is one of the values selected.
+func BodyJSONPathContains(path string, value interface{}) Predicate {
+	return describe("BodyJSONPathContains",
+		ExtractedValueAccepted(extractor.ExtractJSONPath(path), JSONContains(value)),
+		path, value)
+}
+
+// BodyJSONPathExists checks to see if the JSONPath expression selects at least one value, even if it is null.
+func BodyJSONPathExists(path string) Predicate {
+	return describe("BodyJSONPathExists",
+		ExtractedValueAccepted(extractor.ExtractJSONPathAll(path), JSONNotEmpty()),
+		path)
+}
+
+// BodyJSONPointerEquals checks to see if the value referenced by the JSON Pointer equals 'value'.  See JSONEquals for
+// how the values are compared.
+func BodyJSONPointerEquals(pointer string, value interface{}) Predicate {
+	return describe("BodyJSONPointerEquals",
+		ExtractedValueAccepted(extractor.ExtractJSONPointer(pointer), JSONEquals(value)),
+		pointer, value)
+}
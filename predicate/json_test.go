@@ -0,0 +1,86 @@
+package predicate_test
+
+import (
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const orderJSON = `{
+  "id": "A-42",
+  "total": 12,
+  "paid": true,
+  "note": null,
+  "tags": ["vip", "early"],
+  "items": [{"sku": "a-1", "qty": 1}, {"sku": "b-2", "qty": 3}],
+  "customer": {"name": "Ann"}
+}`
+
+var jsonTests = []struct {
+	Name           string
+	Pred           Predicate
+	ExpectedResult bool
+}{
+	{"Equals string", BodyJSONPathEquals("$.id", "A-42"), true},
+	{"Equals string no match", BodyJSONPathEquals("$.id", "a-42"), false},
+	{"Equals int", BodyJSONPathEquals("$.total", 12), true},
+	{"Equals float", BodyJSONPathEquals("$.total", 12.0), true},
+	{"Equals string for number", BodyJSONPathEquals("$.total", "12"), false},
+	{"Equals bool", BodyJSONPathEquals("$.paid", true), true},
+	{"Equals null", BodyJSONPathEquals("$.note", nil), true},
+	{"Equals array", BodyJSONPathEquals("$.tags", []string{"vip", "early"}), true},
+	{"Equals object", BodyJSONPathEquals("$.customer", map[string]string{"name": "Ann"}), true},
+	{"Equals struct", BodyJSONPathEquals("$.items[0]", struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}{"a-1", 1}), true},
+	{"Equals wildcard", BodyJSONPathEquals("$.items[*].qty", []int{1, 3}), true},
+	{"EqualsIgnoreCase", BodyJSONPathEqualsIgnoreCase("$.id", "a-42"), true},
+	{"EqualsIgnoreCase no match", BodyJSONPathEqualsIgnoreCase("$.id", "a-43"), false},
+	{"EqualsIgnoreCase not a string", BodyJSONPathEqualsIgnoreCase("$.total", "12"), false},
+	{"Matches", BodyJSONPathMatches("$.id", regexp.MustCompile("^A-[0-9]+$")), true},
+	{"Matches no match", BodyJSONPathMatches("$.id", regexp.MustCompile("^B-")), false},
+	{"Matches number", BodyJSONPathMatches("$.total", regexp.MustCompile("^1[0-9]$")), true},
+	{"Contains", BodyJSONPathContains("$.tags", "vip"), true},
+	{"Contains no match", BodyJSONPathContains("$.tags", "new"), false},
+	{"Contains wildcard", BodyJSONPathContains("$.items[*].sku", "b-2"), true},
+	{"Contains not an array", BodyJSONPathContains("$.id", "A-42"), false},
+	{"Exists", BodyJSONPathExists("$.customer.name"), true},
+	{"Exists null", BodyJSONPathExists("$.note"), true},
+	{"Exists missing", BodyJSONPathExists("$.customer.email"), false},
+	{"Exists wildcard", BodyJSONPathExists("$.items[*].sku"), true},
+	{"Pointer equals", BodyJSONPointerEquals("/items/1/qty", 3), true},
+	{"Pointer equals no match", BodyJSONPointerEquals("/items/1/qty", 4), false},
+}
+
+func TestBodyJSONPath(t *testing.T) {
+	for _, tst := range jsonTests {
+		t.Run(tst.Name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(orderJSON))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tst.ExpectedResult, tst.Pred.Accept(req))
+			}
+		})
+	}
+}
+
+func TestBodyJSONPath_Explain(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(orderJSON))
+	assert.NoError(t, err)
+	_, explanation := Explain(BodyJSONPathEquals("$.total", 13), req)
+	assert.Equal(t, "json path $.total: expected 13, got 12", explanation)
+
+	req, err = http.NewRequest("POST", "http://foo.com/orders", strings.NewReader(orderJSON))
+	assert.NoError(t, err)
+	_, explanation = Explain(BodyJSONPathExists("$.customer.email"), req)
+	assert.Equal(t, "all json path $.customer.email: expected a value, got []", explanation)
+}
+
+func TestJSONPredicates_String(t *testing.T) {
+	assert.Equal(t, `BodyJSONPathEquals("$.total", 13)`, BodyJSONPathEquals("$.total", 13).(Describer).Describe().String())
+	assert.Equal(t, `JSONNotEmpty()`, JSONNotEmpty().(Describer).Describe().String())
+	assert.Equal(t, `JSONContains("a")`, JSONContains("a").(Describer).Describe().String())
+}
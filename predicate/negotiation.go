@@ -0,0 +1,264 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"strings"
+)
+
+// NegotiationPredicate is the Predicate returned by MediaTypeIs, IncludesMediaType, IncludesLanguage and
+// IncludesEncoding.  Operator is one of "is media type", "includes media type", "includes language" or "includes
+// encoding".  Expected is the media type, language tag or content coding passed to the function that built the
+// predicate.  MediaTypeIs tests an extractor.MediaType, like the one returned by extractor.ExtractContentType; the
+// others test extractor.WeightedValues, like the ones returned by extractor.ExtractAccept.  Strings are parsed into
+// either.  Any other value is rejected.
+type NegotiationPredicate struct {
+	Operator string
+	Expected string
+	Func     func(interface{}) (bool, error)
+}
+
+// MediaTypeIs returns a predicate that returns true if the media type is included in the media range 'mediaRange',
+// e.g. "application/json", "text/*", "application/*+json" or "text/plain; charset=utf-8".  See
+// extractor.MediaType.Includes.  MediaTypeIs panics if the media range can not be parsed.
+func MediaTypeIs(mediaRange string) Predicate {
+	expected := extractor.MustParseMediaType(mediaRange)
+	return NegotiationPredicate{"is media type", mediaRange, func(v interface{}) (bool, error) {
+		mt, err := asMediaType(v)
+		if err != nil {
+			return false, err
+		}
+		return expected.Includes(mt), nil
+	}}
+}
+
+// IncludesMediaType returns a predicate that returns true if the media ranges of an Accept header accept
+// 'mediaType'.  The most specific range that includes the media type decides: it is accepted unless that range has a
+// quality of 0.  A media type with wildcards, like "application/*", is also accepted if it includes one of the ranges
+// and that range has a quality above 0.  An empty list of ranges, i.e. a request without an Accept header, accepts
+// any media type.  IncludesMediaType panics if the media type can not be parsed.
+func IncludesMediaType(mediaType string) Predicate {
+	target := extractor.MustParseMediaType(mediaType)
+	return NegotiationPredicate{"includes media type", mediaType, weighted(func(wvs extractor.WeightedValues) bool {
+		ranges := make([]extractor.MediaType, 0, len(wvs))
+		for _, wv := range wvs {
+			value := wv.Value
+			if value == "*" {
+				value = "*/*"
+			}
+			typ, subtype, _ := strings.Cut(value, "/")
+			ranges = append(ranges, extractor.MediaType{Type: typ, Subtype: subtype, Params: wv.Params})
+		}
+		return negotiate(wvs, func(i int) int {
+			if !ranges[i].Includes(target) {
+				return -1
+			}
+			return mediaRangeSpecificity(ranges[i])
+		}, func(i int) bool {
+			return target.Includes(ranges[i])
+		})
+	})}
+}
+
+func mediaRangeSpecificity(mt extractor.MediaType) int {
+	specificity := len(mt.Params) * 4
+	switch {
+	case mt.Type == "*":
+	case mt.Subtype == "*":
+		specificity++
+	case strings.HasPrefix(mt.Subtype, "*+"):
+		specificity += 2
+	default:
+		specificity += 3
+	}
+	return specificity
+}
+
+// IncludesLanguage returns a predicate that returns true if the language ranges of an Accept-Language header accept
+// the language tag 'tag'.  A range includes the tags that equal it or start with it followed by a '-', e.g. "en"
+// includes "en-US", and "*" includes any tag.  The longest range that includes the tag decides: it is accepted
+// unless that range has a quality of 0.  The tag is also accepted if it includes one of the ranges and that range has
+// a quality above 0, e.g. "en" is accepted by "en-US".  An empty list of ranges accepts any language.
+func IncludesLanguage(tag string) Predicate {
+	target := strings.ToLower(tag)
+	return NegotiationPredicate{"includes language", tag, weighted(func(wvs extractor.WeightedValues) bool {
+		return negotiate(wvs, func(i int) int {
+			if !languageIncludes(wvs[i].Value, target) {
+				return -1
+			}
+			return len(wvs[i].Value)
+		}, func(i int) bool {
+			return languageIncludes(target, wvs[i].Value)
+		})
+	})}
+}
+
+func languageIncludes(languageRange, tag string) bool {
+	return languageRange == "*" || languageRange == tag || strings.HasPrefix(tag, languageRange+"-")
+}
+
+// IncludesEncoding returns a predicate that returns true if the content codings of an Accept-Encoding header accept
+// the content coding 'coding', e.g. "gzip".  The coding itself or, failing that, "*" decides: it is accepted unless
+// it has a quality of 0.  "identity" is accepted unless it is excluded.  An empty list of codings accepts any coding.
+func IncludesEncoding(coding string) Predicate {
+	target := strings.ToLower(coding)
+	return NegotiationPredicate{"includes encoding", coding, weighted(func(wvs extractor.WeightedValues) bool {
+		if target == "identity" {
+			mentioned := false
+			for _, wv := range wvs {
+				mentioned = mentioned || wv.Value == "identity" || wv.Value == "*"
+			}
+			if !mentioned {
+				return true
+			}
+		}
+		return negotiate(wvs, func(i int) int {
+			return encodingSpecificity(wvs[i].Value, target)
+		}, func(i int) bool {
+			return target == "*"
+		})
+	})}
+}
+
+func encodingSpecificity(coding, target string) int {
+	switch coding {
+	case target:
+		return 1
+	case "*":
+		return 0
+	}
+	return -1
+}
+
+// negotiate decides whether the weighted values accept a target.  specificity returns how specifically the i'th value
+// includes the target or -1 if it does not; the most specific value decides unless its quality is 0.  includedBy
+// returns true if the target includes the i'th value, which accepts the target if its quality is above 0.  An empty
+// list accepts any target.
+func negotiate(wvs extractor.WeightedValues, specificity func(int) int, includedBy func(int) bool) bool {
+	if len(wvs) == 0 {
+		return true
+	}
+	best, decider := -1, -1
+	for i := range wvs {
+		if s := specificity(i); s > best {
+			best, decider = s, i
+		}
+	}
+	if decider >= 0 && wvs[decider].Q > 0 {
+		return true
+	}
+	for i, wv := range wvs {
+		if wv.Q > 0 && includedBy(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func weighted(f func(extractor.WeightedValues) bool) func(interface{}) (bool, error) {
+	return func(v interface{}) (bool, error) {
+		var wvs extractor.WeightedValues
+		switch value := v.(type) {
+		case extractor.WeightedValues:
+			wvs = value
+		case []extractor.WeightedValue:
+			wvs = value
+		case string:
+			var err error
+			if wvs, err = extractor.ParseWeightedValues(value); err != nil {
+				return false, err
+			}
+		default:
+			return false, fmt.Errorf("%w: expected extractor.WeightedValues, got %T", extractor.ErrWrongType, v)
+		}
+		return f(wvs), nil
+	}
+}
+
+func asMediaType(v interface{}) (extractor.MediaType, error) {
+	switch value := v.(type) {
+	case extractor.MediaType:
+		return value, nil
+	case string:
+		return extractor.ParseMediaType(value)
+	}
+	return extractor.MediaType{}, fmt.Errorf("%w: expected an extractor.MediaType, got %T", extractor.ErrWrongType, v)
+}
+
+// Accept returns true if the value passed satisfies the test.
+func (np NegotiationPredicate) Accept(v interface{}) bool {
+	accepted, err := np.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not of the type the predicate tests, and an
+// error wrapping extractor.ErrParse if it is a string that can not be parsed.
+func (np NegotiationPredicate) AcceptE(v interface{}) (bool, error) {
+	return np.Func(v)
+}
+
+// Evaluate tests the value and records the test made.
+func (np NegotiationPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := np.AcceptE(v)
+	return &Result{Predicate: np, Accepted: accepted, Value: v, Operator: np.Operator, Expected: np.Expected, Err: err}
+}
+
+var negotiationPredicateNames = map[string]string{
+	"is media type":       "MediaTypeIs",
+	"includes media type": "IncludesMediaType",
+	"includes language":   "IncludesLanguage",
+	"includes encoding":   "IncludesEncoding",
+}
+
+// Describe returns the name of the function that built the predicate and its argument.
+func (np NegotiationPredicate) Describe() Description {
+	return Description{Name: negotiationPredicateNames[np.Operator], Args: []interface{}{np.Expected}}
+}
+
+// String renders the predicate as a function call, e.g. IncludesMediaType("application/json").
+func (np NegotiationPredicate) String() string {
+	return np.Describe().String()
+}
+
+// ContentTypeIs returns a predicate that returns true if the Content-Type of the request is included in the media
+// range 'mediaRange', e.g. ContentTypeIs("application/json") accepts "application/json; charset=utf-8" and
+// ContentTypeIs("application/*+json") accepts "application/problem+json".  A request without a Content-Type is
+// rejected.  ContentTypeIs panics if the media range can not be parsed.
+func ContentTypeIs(mediaRange string) Predicate {
+	return describe("ContentTypeIs", ExtractedValueAccepted(extractor.ExtractContentType(), MediaTypeIs(mediaRange)),
+		mediaRange)
+}
+
+// AcceptsMediaType returns a predicate that returns true if the Accept header of the request accepts 'mediaType'.
+// See IncludesMediaType.
+func AcceptsMediaType(mediaType string) Predicate {
+	return describe("AcceptsMediaType",
+		ExtractedValueAccepted(extractor.ExtractAccept(), IncludesMediaType(mediaType)),
+		mediaType)
+}
+
+// AcceptsLanguage returns a predicate that returns true if the Accept-Language header of the request accepts the
+// language tag 'tag'.  See IncludesLanguage.
+func AcceptsLanguage(tag string) Predicate {
+	return describe("AcceptsLanguage", ExtractedValueAccepted(extractor.ExtractAcceptLanguage(), IncludesLanguage(tag)),
+		tag)
+}
+
+// AcceptsEncoding returns a predicate that returns true if the Accept-Encoding header of the request accepts the
+// content coding 'coding'.  See IncludesEncoding.
+func AcceptsEncoding(coding string) Predicate {
+	return describe("AcceptsEncoding",
+		ExtractedValueAccepted(extractor.ExtractAcceptEncoding(), IncludesEncoding(coding)),
+		coding)
+}
@@ -0,0 +1,88 @@
+package predicate_test
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+var negotiationTests = []struct {
+	Name           string
+	Pred           Predicate
+	Value          string
+	ExpectedResult bool
+}{
+	{"MediaTypeIs Match", MediaTypeIs("application/json"), "application/json; charset=utf-8", true},
+	{"MediaTypeIs Suffix", MediaTypeIs("application/*+json"), "application/problem+json", true},
+	{"MediaTypeIs No Match", MediaTypeIs("application/json"), "application/problem+json", false},
+	{"IncludesMediaType No Header", IncludesMediaType("application/json"), "", true},
+	{"IncludesMediaType Exact", IncludesMediaType("application/json"), "text/html, application/json;q=0.5", true},
+	{"IncludesMediaType Wildcard", IncludesMediaType("image/png"), "text/html, */*;q=0.1", true},
+	{"IncludesMediaType Not Listed", IncludesMediaType("image/png"), "text/html, application/*", false},
+	{"IncludesMediaType Excluded", IncludesMediaType("text/plain"), "text/*, text/plain;q=0", false},
+	{"IncludesMediaType Excluded By Wildcard", IncludesMediaType("text/plain"), "application/json, */*;q=0", false},
+	{"IncludesMediaType Range", IncludesMediaType("application/*"), "application/*;q=0, application/json", true},
+	{"IncludesMediaType Range Excluded", IncludesMediaType("application/*"), "application/json;q=0, text/*", false},
+	{"IncludesMediaType Params", IncludesMediaType("text/html; level=1"), "text/html;level=1;q=0, text/html", false},
+	{"IncludesLanguage Prefix", IncludesLanguage("en-GB"), "fr, en;q=0.5", true},
+	{"IncludesLanguage Broader Tag", IncludesLanguage("EN"), "en-US", true},
+	{"IncludesLanguage Excluded", IncludesLanguage("en-GB"), "en, en-GB;q=0", false},
+	{"IncludesLanguage Not Listed", IncludesLanguage("de"), "en, fr", false},
+	{"IncludesLanguage Star", IncludesLanguage("de"), "en, *;q=0.1", true},
+	{"IncludesEncoding Match", IncludesEncoding("gzip"), "br, gzip;q=0.5", true},
+	{"IncludesEncoding Not Listed", IncludesEncoding("gzip"), "br", false},
+	{"IncludesEncoding Star", IncludesEncoding("gzip"), "br, *", true},
+	{"IncludesEncoding Excluded", IncludesEncoding("gzip"), "gzip;q=0, *", false},
+	{"IncludesEncoding Identity", IncludesEncoding("identity"), "gzip", true},
+	{"IncludesEncoding Identity Excluded", IncludesEncoding("identity"), "gzip, *;q=0", false},
+}
+
+func TestNegotiationPredicates(t *testing.T) {
+	for _, tst := range negotiationTests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert.Equal(t, tst.ExpectedResult, tst.Pred.Accept(tst.Value))
+		})
+	}
+
+	_, err := AcceptE(IncludesEncoding("gzip"), 5)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+	_, err = AcceptE(MediaTypeIs("text/*"), "text")
+	assert.True(t, errors.Is(err, extractor.ErrParse), "%v", err)
+	assert.Panics(t, func() { MediaTypeIs("text") })
+	assert.Equal(t, `IncludesLanguage("en")`, IncludesLanguage("en").(NegotiationPredicate).String())
+}
+
+func TestNegotiationRequestPredicates(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://foo.com/a", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.False(t, ContentTypeIs("application/json").Accept(req))
+	assert.True(t, AcceptsMediaType("application/json").Accept(req))
+	assert.True(t, AcceptsLanguage("en").Accept(req))
+	assert.True(t, AcceptsEncoding("gzip").Accept(req))
+
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	req.Header.Set("Accept", "application/json, application/xml;q=0")
+	req.Header.Set("Accept-Language", "en-US, *;q=0")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	assert.True(t, ContentTypeIs("application/*+json").Accept(req))
+	assert.True(t, AcceptsMediaType("application/*").Accept(req))
+	assert.False(t, AcceptsMediaType("application/xml").Accept(req))
+	assert.True(t, AcceptsLanguage("en").Accept(req))
+	assert.False(t, AcceptsLanguage("fr").Accept(req))
+	assert.True(t, AcceptsEncoding("deflate").Accept(req))
+	assert.False(t, AcceptsEncoding("br").Accept(req))
+
+	accepted, explanation := Explain(ContentTypeIs("application/xml"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "content type: expected to be included in 'application/xml', got 'application/vnd.api+json'",
+		explanation)
+	accepted, explanation = Explain(AcceptsMediaType("application/xml"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "header Accept: expected to accept 'application/xml', got 'application/json, application/xml;q=0'",
+		explanation)
+	assert.Equal(t, `AcceptsLanguage("fr")`, AcceptsLanguage("fr").(DescribedPredicate).String())
+}
@@ -0,0 +1,220 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"math"
+	"reflect"
+	"time"
+)
+
+// ComparisonPredicate is the Predicate returned by GreaterThan, LessThan, Between and NumericEquals.  Operator is one
+// of "greater than", "less than", "between" or "equals number".  Bounds are the values passed to the function that
+// built the predicate.  The value tested is compared with each bound and Func decides from the results, which are -1,
+// 0 or +1 as the value is less than, equal to or greater than the bound.
+//
+// Numbers of any type are compared with numbers: as int64 if both are integers and as float64 otherwise.  A
+// time.Duration is an integer number of nanoseconds.  A time.Time is compared with a time.Time.  Any other value,
+// notably a string, is rejected with an error wrapping extractor.ErrWrongType; use extractor.AsInt, AsFloat,
+// AsDuration or AsTime to parse strings first.
+type ComparisonPredicate struct {
+	Operator string
+	Bounds   []interface{}
+	Func     func(comparisons []int) bool
+}
+
+func comparison(name, operator string, f func([]int) bool, bounds ...interface{}) Predicate {
+	for _, bound := range bounds {
+		if _, isTime := bound.(time.Time); !isTime && !isNumber(bound) {
+			panic(fmt.Sprintf("predicate: %s: %T is neither a number nor a time.Time", name, bound))
+		}
+	}
+	return ComparisonPredicate{Operator: operator, Bounds: bounds, Func: f}
+}
+
+// GreaterThan returns a predicate that returns true if the value is greater than 'bound', or after it if it is a
+// time.Time.  GreaterThan panics if the bound is neither a number nor a time.Time.
+func GreaterThan(bound interface{}) Predicate {
+	return comparison("GreaterThan", "greater than", func(c []int) bool { return c[0] > 0 }, bound)
+}
+
+// LessThan returns a predicate that returns true if the value is less than 'bound', or before it if it is a
+// time.Time.  LessThan panics if the bound is neither a number nor a time.Time.
+func LessThan(bound interface{}) Predicate {
+	return comparison("LessThan", "less than", func(c []int) bool { return c[0] < 0 }, bound)
+}
+
+// Between returns a predicate that returns true if the value is between 'min' and 'max', both included.  Between
+// panics if a bound is neither a number nor a time.Time.
+func Between(min, max interface{}) Predicate {
+	return comparison("Between", "between", func(c []int) bool { return c[0] >= 0 && c[1] <= 0 }, min, max)
+}
+
+// NumericEquals returns a predicate that returns true if the value is numerically equal to 'expected', e.g.
+// NumericEquals(200) accepts int 200, int64 200 and float64 200.  Times are equal if they are the same instant.
+// NumericEquals panics if the expected value is neither a number nor a time.Time.
+func NumericEquals(expected interface{}) Predicate {
+	return comparison("NumericEquals", "equals number", func(c []int) bool { return c[0] == 0 }, expected)
+}
+
+func isNumber(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.CanInt() || rv.CanUint() || rv.CanFloat()
+}
+
+// compare returns -1, 0 or +1 as the value is less than, equal to or greater than the bound.
+func compare(v, bound interface{}) (int, error) {
+	if b, ok := bound.(time.Time); ok {
+		t, ok := v.(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("%w: expected a time.Time, got %T", extractor.ErrWrongType, v)
+		}
+		switch {
+		case t.Before(b):
+			return -1, nil
+		case t.After(b):
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if !isNumber(v) {
+		return 0, fmt.Errorf("%w: expected a number, got %T", extractor.ErrWrongType, v)
+	}
+	rv, rb := reflect.ValueOf(v), reflect.ValueOf(bound)
+	if rv.CanInt() && rb.CanInt() {
+		switch i, b := rv.Int(), rb.Int(); {
+		case i < b:
+			return -1, nil
+		case i > b:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	f, b := toFloat(rv), toFloat(rb)
+	switch {
+	case math.IsNaN(f) || math.IsNaN(b):
+		return 0, fmt.Errorf("%w: NaN can not be compared", extractor.ErrParse)
+	case f < b:
+		return -1, nil
+	case f > b:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func toFloat(rv reflect.Value) float64 {
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int())
+	case rv.CanUint():
+		return float64(rv.Uint())
+	}
+	return rv.Float()
+}
+
+// Accept returns true if the value passed satisfies the comparison.
+func (cp ComparisonPredicate) Accept(v interface{}) bool {
+	accepted, err := cp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value can not be compared with the bounds.
+func (cp ComparisonPredicate) AcceptE(v interface{}) (bool, error) {
+	comparisons := make([]int, 0, len(cp.Bounds))
+	for _, bound := range cp.Bounds {
+		c, err := compare(v, bound)
+		if err != nil {
+			return false, err
+		}
+		comparisons = append(comparisons, c)
+	}
+	return cp.Func(comparisons), nil
+}
+
+// Evaluate tests the value and records the comparison made.  Expected is the bound, or the slice of both bounds for
+// Between.
+func (cp ComparisonPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := cp.AcceptE(v)
+	var expected interface{} = cp.Bounds
+	if len(cp.Bounds) == 1 {
+		expected = cp.Bounds[0]
+	}
+	return &Result{Predicate: cp, Accepted: accepted, Value: v, Operator: cp.Operator, Expected: expected, Err: err}
+}
+
+var comparisonPredicateNames = map[string]string{
+	"greater than":  "GreaterThan",
+	"less than":     "LessThan",
+	"between":       "Between",
+	"equals number": "NumericEquals",
+}
+
+// Describe returns the name of the function that built the predicate and its bounds.
+func (cp ComparisonPredicate) Describe() Description {
+	return Description{Name: comparisonPredicateNames[cp.Operator], Args: cp.Bounds}
+}
+
+// String renders the predicate as a function call, e.g. Between(1, 10).
+func (cp ComparisonPredicate) String() string {
+	return cp.Describe().String()
+}
+
+// QueryParamGreaterThan returns a predicate that returns true if the query parameter named 'name' is a number greater
+// than 'bound'.  A request without the parameter, or whose parameter is not a number, is rejected.  See
+// extractor.AsFloat.
+func QueryParamGreaterThan(name string, bound float64) Predicate {
+	return describe("QueryParamGreaterThan",
+		ExtractedValueAccepted(extractor.AsFloat(extractor.ExtractQueryParameter(name)), GreaterThan(bound)),
+		name, bound)
+}
+
+// QueryParamLessThan returns a predicate that returns true if the query parameter named 'name' is a number less than
+// 'bound'.  A request without the parameter, or whose parameter is not a number, is rejected.
+func QueryParamLessThan(name string, bound float64) Predicate {
+	return describe("QueryParamLessThan",
+		ExtractedValueAccepted(extractor.AsFloat(extractor.ExtractQueryParameter(name)), LessThan(bound)),
+		name, bound)
+}
+
+// QueryParamBetween returns a predicate that returns true if the query parameter named 'name' is a number between
+// 'min' and 'max', both included.  A request without the parameter, or whose parameter is not a number, is rejected.
+func QueryParamBetween(name string, min, max float64) Predicate {
+	return describe("QueryParamBetween",
+		ExtractedValueAccepted(extractor.AsFloat(extractor.ExtractQueryParameter(name)), Between(min, max)),
+		name, min, max)
+}
+
+// ContentLengthGreaterThan returns a predicate that returns true if the Content-Length of the request or response is
+// greater than 'length'.  A message whose length is unknown is rejected.  See extractor.ExtractContentLength.
+func ContentLengthGreaterThan(length int64) Predicate {
+	return describe("ContentLengthGreaterThan",
+		ExtractedValueAccepted(extractor.ExtractContentLength(), GreaterThan(length)),
+		length)
+}
+
+// ContentLengthLessThan returns a predicate that returns true if the Content-Length of the request or response is
+// less than 'length'.  A message whose length is unknown is rejected.
+func ContentLengthLessThan(length int64) Predicate {
+	return describe("ContentLengthLessThan",
+		ExtractedValueAccepted(extractor.ExtractContentLength(), LessThan(length)),
+		length)
+}
+
+// ContentLengthBetween returns a predicate that returns true if the Content-Length of the request or response is
+// between 'min' and 'max', both included.  A message whose length is unknown is rejected.
+func ContentLengthBetween(min, max int64) Predicate {
+	return describe("ContentLengthBetween",
+		ExtractedValueAccepted(extractor.ExtractContentLength(), Between(min, max)),
+		min, max)
+}
@@ -0,0 +1,73 @@
+package predicate_test
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComparisons(t *testing.T) {
+	assert.True(t, GreaterThan(10).Accept(int64(11)))
+	assert.False(t, GreaterThan(10).Accept(10.0))
+	assert.True(t, GreaterThan(0.5).Accept(1))
+	assert.True(t, LessThan(uint8(10)).Accept(-1))
+	assert.True(t, Between(1, 10).Accept(1))
+	assert.True(t, Between(1, 10).Accept(10.0))
+	assert.False(t, Between(1, 10).Accept(10.5))
+	assert.True(t, NumericEquals(200).Accept(200.0))
+	assert.True(t, GreaterThan(time.Second).Accept(2*time.Second))
+
+	now := time.Now()
+	assert.True(t, GreaterThan(now).Accept(now.Add(time.Minute)))
+	assert.True(t, NumericEquals(now).Accept(now.UTC()))
+
+	accepted, err := AcceptE(GreaterThan(10), "11")
+	assert.False(t, accepted)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+	_, err = AcceptE(GreaterThan(now), 11)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+	assert.Panics(t, func() { GreaterThan("10") })
+
+	assert.Equal(t, "Between(1, 10)", Between(1, 10).(ComparisonPredicate).String())
+	assert.Equal(t, "NumericEquals(200)", NumericEquals(200).(ComparisonPredicate).String())
+}
+
+func TestComparisonExplanations(t *testing.T) {
+	_, explanation := Explain(Between(1, 10), 11)
+	assert.Equal(t, "expected between 1 and 10, got 11", explanation)
+	_, explanation = Explain(Not(NumericEquals(3)), 3)
+	assert.Equal(t, "expected not to equal 3, got 3", explanation)
+	_, explanation = Explain(GreaterThan(5), 1)
+	assert.Equal(t, "expected greater than 5, got 1", explanation)
+}
+
+func TestQueryParamComparisons(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a?limit=50&page=x", nil)
+	assert.True(t, QueryParamGreaterThan("limit", 10).Accept(req))
+	assert.False(t, QueryParamLessThan("limit", 10).Accept(req))
+	assert.True(t, QueryParamBetween("limit", 1, 100).Accept(req))
+	assert.False(t, QueryParamGreaterThan("page", 0).Accept(req))
+	assert.False(t, QueryParamGreaterThan("missing", 0).Accept(req))
+
+	_, err := AcceptE(WithErrorPolicy(QueryParamGreaterThan("page", 0), PropagateError), req)
+	assert.True(t, errors.Is(err, extractor.ErrParse), "%v", err)
+
+	_, explanation := Explain(QueryParamBetween("limit", 1, 10), req)
+	assert.Equal(t, "query parameter limit as float: expected between 1 and 10, got 50", explanation)
+}
+
+func TestContentLengthComparisons(t *testing.T) {
+	req := httptest.NewRequest("POST", "/a", strings.NewReader("hello"))
+	assert.True(t, ContentLengthGreaterThan(0).Accept(req))
+	assert.True(t, ContentLengthLessThan(6).Accept(req))
+	assert.True(t, ContentLengthBetween(5, 5).Accept(req))
+	req.ContentLength = -1
+	assert.False(t, ContentLengthGreaterThan(0).Accept(req))
+	_, explanation := Explain(ContentLengthGreaterThan(0), req)
+	assert.Equal(t, "content length: value missing: content length is unknown", explanation)
+}
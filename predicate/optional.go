@@ -0,0 +1,74 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+)
+
+// OptionalPredicate is the Predicate returned by IsPresent and IsAbsent.  It tests an extractor.Optional using
+// Operator, which is either "is present" or "is absent".  Any other value is rejected.
+type OptionalPredicate struct {
+	Operator string
+	Func     func(extractor.Optional) bool
+}
+
+// IsPresent returns a predicate that returns true if the value passed is an extractor.Optional that holds a value.
+func IsPresent() Predicate {
+	return OptionalPredicate{Operator: "is present", Func: func(o extractor.Optional) bool {
+		return o.Present
+	}}
+}
+
+// IsAbsent returns a predicate that returns true if the value passed is an extractor.Optional that holds no value.
+func IsAbsent() Predicate {
+	return OptionalPredicate{Operator: "is absent", Func: func(o extractor.Optional) bool {
+		return !o.Present
+	}}
+}
+
+// Accept returns true if the value passed is an extractor.Optional that satisfies the test.
+func (op OptionalPredicate) Accept(v interface{}) bool {
+	accepted, err := op.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not an extractor.Optional.
+func (op OptionalPredicate) AcceptE(v interface{}) (bool, error) {
+	o, ok := v.(extractor.Optional)
+	if !ok {
+		return false, fmt.Errorf("%w: expected an extractor.Optional, got %T", extractor.ErrWrongType, v)
+	}
+	return op.Func(o), nil
+}
+
+// Evaluate tests the value and records the test made.
+func (op OptionalPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := op.AcceptE(v)
+	return &Result{Predicate: op, Accepted: accepted, Value: v, Operator: op.Operator, Err: err}
+}
+
+var optionalPredicateNames = map[string]string{
+	"is present": "IsPresent",
+	"is absent":  "IsAbsent",
+}
+
+// Describe returns the name of the function that built the predicate.
+func (op OptionalPredicate) Describe() Description {
+	return Description{Name: optionalPredicateNames[op.Operator]}
+}
+
+// String renders the predicate as a function call, e.g. IsPresent().
+func (op OptionalPredicate) String() string {
+	return op.Describe().String()
+}
@@ -12,21 +12,83 @@ package predicate
 // specific language governing permissions and limitations under the License.
 
 import (
+	"fmt"
 	"github.com/danapsimer/go-http-matchers/extractor"
 	"regexp"
 )
 
 // PathMatches returns a predicate that returns true if the path matches the pathRegex.
 func PathMatches(pathRegex *regexp.Regexp) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractPath(), StringMatches(pathRegex))
+	return describe("PathMatches", ExtractedValueAccepted(extractor.ExtractPath(), StringMatches(pathRegex)), pathRegex)
 }
 
 // PathEquals returns a predicate that returns true if the path equals 'path'
 func PathEquals(path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractPath(), StringEquals(path))
+	return describe("PathEquals", ExtractedValueAccepted(extractor.ExtractPath(), StringEquals(path)), path)
 }
 
 // PathStartsWith returns a predicate that returns true if the path starts with 'path'
 func PathStartsWith(path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractPath(), StringStartsWith(path))
+	return describe("PathStartsWith", ExtractedValueAccepted(extractor.ExtractPath(), StringStartsWith(path)), path)
+}
+
+// PathTemplate returns a predicate that returns true if the path matches the template, e.g.
+// "/users/{id}/orders/{orderId:[0-9]+}".  See extractor.PathTemplate for the syntax of templates and
+// extractor.ExtractPathParameters for extracting the parameters it captures.  PathTemplate panics if the template is
+// not valid.
+func PathTemplate(template string) Predicate {
+	return describe("PathTemplate", ExtractedValueAccepted(extractor.ExtractPath(), StringMatchesTemplate(template)),
+		template)
+}
+
+// TemplatePredicate is the Predicate returned by StringMatchesTemplate.  It tests whether a path matches Template.
+type TemplatePredicate struct {
+	Template *extractor.PathTemplate
+}
+
+// StringMatchesTemplate returns a predicate that returns true if the value passed is a path that matches the template.
+// StringMatchesTemplate panics if the template is not valid.
+func StringMatchesTemplate(template string) Predicate {
+	return TemplatePredicate{Template: extractor.MustCompilePathTemplate(template)}
+}
+
+// Accept returns true if the value passed is a string that matches the template.  Any other value is rejected.
+func (tp TemplatePredicate) Accept(v interface{}) bool {
+	accepted, err := tp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a string.
+func (tp TemplatePredicate) AcceptE(v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, fmt.Errorf("%w: expected a string, got %T", extractor.ErrWrongType, v)
+	}
+	_, matched := tp.Template.Match(s)
+	return matched, nil
+}
+
+// Evaluate matches the value against the template and records the match made.
+func (tp TemplatePredicate) Evaluate(v interface{}) *Result {
+	accepted, err := tp.AcceptE(v)
+	return &Result{Predicate: tp, Accepted: accepted, Value: v, Operator: "matches template",
+		Expected: tp.Template.String(), Err: err}
+}
+
+// Describe returns the name of the function that built the predicate and the template.
+func (tp TemplatePredicate) Describe() Description {
+	return Description{Name: "StringMatchesTemplate", Args: []interface{}{tp.Template.String()}}
+}
+
+// String renders the predicate as a function call, e.g. StringMatchesTemplate("/users/{id}").
+func (tp TemplatePredicate) String() string {
+	return tp.Describe().String()
+}
+
+// PathParamEquals returns a predicate that returns true if the template matches the path and the parameter named
+// 'name' that it captures equals 'value'.
+func PathParamEquals(template, name, value string) Predicate {
+	return describe("PathParamEquals",
+		ExtractedValueAccepted(extractor.ExtractPathParameter(template, name), StringEquals(value)),
+		template, name, value)
 }
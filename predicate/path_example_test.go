@@ -35,3 +35,12 @@ func ExamplePathStartsWith() {
 	// true
 	// false
 }
+
+func ExamplePathTemplate() {
+	req, _ := http.NewRequest("GET", "http://foo.com/users/ann/orders/7", nil)
+	fmt.Printf("%v\n", PathTemplate("/users/{id}/orders/{orderId:[0-9]+}").Accept(req))
+	fmt.Printf("%v\n", PathTemplate("/users/{id}").Accept(req))
+	// Output:
+	// true
+	// false
+}
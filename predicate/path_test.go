@@ -1,6 +1,9 @@
 package predicate_test
 
 import (
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
 	. "github.com/danapsimer/go-http-matchers/predicate"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -30,3 +33,41 @@ func TestPathStartsWith(t *testing.T) {
 	assert.True(t, PathStartsWith("/test/foo/").Accept(req))
 	assert.False(t, PathStartsWith("/test/bar/").Accept(req))
 }
+
+func TestPathTemplate(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/users/ann/orders/7?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.True(t, PathTemplate("/users/{id}/orders/{orderId:[0-9]+}").Accept(req))
+	assert.True(t, PathTemplate("/users/**").Accept(req))
+	assert.False(t, PathTemplate("/users/{id}").Accept(req))
+	assert.False(t, PathTemplate("/users/{id}/orders/{orderId:[a-z]+}").Accept(req))
+
+	accepted, explanation := Explain(PathTemplate("/users/{id}"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, "path: expected to match template '/users/{id}', got '/users/ann/orders/7'", explanation)
+	assert.Equal(t, `PathTemplate("/users/{id}")`, PathTemplate("/users/{id}").(fmt.Stringer).String())
+}
+
+func TestStringMatchesTemplate(t *testing.T) {
+	p := StringMatchesTemplate("/users/{id}")
+	assert.True(t, p.Accept("/users/ann"))
+	assert.False(t, p.Accept("/users/ann/orders"))
+	assert.Equal(t, `StringMatchesTemplate("/users/{id}")`, p.(fmt.Stringer).String())
+	assert.Equal(t, Description{Name: "StringMatchesTemplate", Args: []interface{}{"/users/{id}"}},
+		p.(Describer).Describe())
+
+	_, err := AcceptE(p, 7)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+
+	accepted, explanation := Explain(Not(p), "/users/ann")
+	assert.False(t, accepted)
+	assert.Equal(t, "expected not to match template '/users/{id}', got '/users/ann'", explanation)
+}
+
+func TestPathParamEquals(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/users/ann/orders/7?q=5&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.True(t, PathParamEquals("/users/{id}/orders/{orderId}", "orderId", "7").Accept(req))
+	assert.False(t, PathParamEquals("/users/{id}/orders/{orderId}", "id", "bob").Accept(req))
+	assert.False(t, PathParamEquals("/users/{id}", "id", "ann").Accept(req))
+}
@@ -36,56 +36,86 @@ func (pf PredicateFunc) Accept(v interface{}) bool {
 // And returns a predicate that is true if all of the passed predicate are true for the input.  Furthermore, it stops
 // executing predicates after the first false one.
 func And(predicates ...Predicate) Predicate {
-	return PredicateFunc(func(v interface{}) bool {
-		for _, p := range predicates {
-			if !p.Accept(v) {
-				return false
-			}
-		}
-		return true
-	})
+	return AndPredicate(predicates)
+}
+
+// AndPredicate is the Predicate returned by And.
+type AndPredicate []Predicate
+
+// Accept returns true if all of the predicates accept the value.  An error propagated by one of the predicates
+// rejects the value.
+func (ap AndPredicate) Accept(v interface{}) bool {
+	accepted, err := ap.AcceptE(v)
+	return accepted && err == nil
 }
 
 // Or returns a predicate that is true if any of the passed predicate are true.  Furthermore, it stops executing
 // predicates after the first true one.
 func Or(predicates ...Predicate) Predicate {
-	return PredicateFunc(func(v interface{}) bool {
-		for _, p := range predicates {
-			if p.Accept(v) {
-				return true
-			}
-		}
-		return false
-	})
+	return OrPredicate(predicates)
+}
+
+// OrPredicate is the Predicate returned by Or.
+type OrPredicate []Predicate
+
+// Accept returns true if any of the predicates accept the value.  An error propagated by one of the predicates rejects
+// the value.
+func (op OrPredicate) Accept(v interface{}) bool {
+	accepted, err := op.AcceptE(v)
+	return accepted && err == nil
 }
 
 // Not returns a predicate that negates the condition defined by the passed predicate.
 func Not(predicate Predicate) Predicate {
-	return PredicateFunc(func(v interface{}) bool {
-		return !predicate.Accept(v)
-	})
+	return NotPredicate{predicate}
+}
+
+// NotPredicate is the Predicate returned by Not.
+type NotPredicate struct {
+	Predicate Predicate
+}
+
+// Accept returns true if the wrapped predicate rejects the value.  An error propagated by the wrapped predicate
+// rejects the value rather than being negated.
+func (np NotPredicate) Accept(v interface{}) bool {
+	accepted, err := np.AcceptE(v)
+	return accepted && err == nil
 }
 
 // True returns a predicate that returns true for all inputs.
 func True() Predicate {
-	return PredicateFunc(func(v interface{}) bool { return true })
+	return describe("True", PredicateFunc(func(v interface{}) bool { return true }))
 }
 
 // False returns a predicate that returns false for all inputs.
 func False() Predicate {
-	return PredicateFunc(func(v interface{}) bool { return false })
+	return describe("False", PredicateFunc(func(v interface{}) bool { return false }))
 }
 
 // ExtractedValueAccepted returns A predicate that extracts a value using the Extractor and passes that value to the
-// provided predicate
+// provided predicate.  The value is rejected if it can not be extracted.  See WithErrorPolicy.
 func ExtractedValueAccepted(extractor extractor.Extractor, predicate Predicate) Predicate {
-	return PredicateFunc(func(v interface{}) bool {
-		return predicate.Accept(extractor.Extract(v))
-	})
+	return ExtractedValuePredicate{Extractor: extractor, Predicate: predicate}
+}
+
+// ExtractedValuePredicate is the Predicate returned by ExtractedValueAccepted.  OnError decides what happens when the
+// value can not be extracted or the predicate can not test it.
+type ExtractedValuePredicate struct {
+	Extractor extractor.Extractor
+	Predicate Predicate
+	OnError   ErrorPolicy
+}
+
+// Accept extracts the value and returns true if the predicate accepts it.
+func (evp ExtractedValuePredicate) Accept(v interface{}) bool {
+	accepted, err := evp.AcceptE(v)
+	return accepted && err == nil
 }
 
 // MethodIs returns a predicate that takes a request, extracts the method, and returns true if it equals the method
 // provided, ignoring case.
 func MethodIs(method string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractMethod()), StringEquals(strings.ToUpper(method)))
+	return describe("MethodIs",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractMethod()), StringEquals(strings.ToUpper(method))),
+		method)
 }
@@ -39,3 +39,9 @@ func ExampleOr() {
 	// false
 	// true
 }
+
+func ExampleDescribedPredicate() {
+	p := predicate.And(predicate.MethodIs("GET"), predicate.PathStartsWith("/api"))
+	fmt.Println(p)
+	// Output: And(MethodIs("GET"), PathStartsWith("/api"))
+}
@@ -20,35 +20,62 @@ import (
 // QueryParamEquals returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if it equals the value provided.
 func QueryParamEquals(name, value string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringEquals(value))
+	return describe("QueryParamEquals",
+		ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringEquals(value)),
+		name, value)
 }
 
 // QueryParamEqualsIgnoreCase returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if it equals the value provided, ignoring case.
 func QueryParamEqualsIgnoreCase(name, value string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractQueryParameter(name)), StringEquals(strings.ToUpper(value)))
+	return describe("QueryParamEqualsIgnoreCase",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractQueryParameter(name)), StringEquals(strings.ToUpper(value))),
+		name, value)
 }
 
 // QueryParamContains returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if it contains the value provided.
 func QueryParamContains(name, value string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringContains(value))
+	return describe("QueryParamContains",
+		ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringContains(value)),
+		name, value)
 }
 
 // QueryParamContainsIgnoreCase returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if it contains the value provided, ignoring case.
 func QueryParamContainsIgnoreCase(name, value string) Predicate {
-	return ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractQueryParameter(name)), StringContains(strings.ToUpper(value)))
+	return describe("QueryParamContainsIgnoreCase",
+		ExtractedValueAccepted(extractor.UpperCaseExtractor(extractor.ExtractQueryParameter(name)), StringContains(strings.ToUpper(value))),
+		name, value)
 }
 
 // QueryParamMatches returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if the value matches the pattern provided.
 func QueryParamMatches(name string, pattern *regexp.Regexp) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringMatches(pattern))
+	return describe("QueryParamMatches",
+		ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringMatches(pattern)),
+		name, pattern)
 }
 
 // QueryParamStartsWith returns a Predicate that takes a request, extracts the query parameter specified and
 // returns true if the value starts with the prefix provided.
 func QueryParamStartsWith(name string, prefix string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringStartsWith(prefix))
+	return describe("QueryParamStartsWith",
+		ExtractedValueAccepted(extractor.ExtractQueryParameter(name), StringStartsWith(prefix)),
+		name, prefix)
+}
+
+// QueryParamExists returns a predicate that returns true if the request has a query parameter named 'name', even if
+// it has no value.
+func QueryParamExists(name string) Predicate {
+	return describe("QueryParamExists",
+		ExtractedValueAccepted(extractor.ExtractOptionalQueryParameter(name), IsPresent()),
+		name)
+}
+
+// QueryParamAbsent returns a predicate that returns true if the request has no query parameter named 'name'.
+func QueryParamAbsent(name string) Predicate {
+	return describe("QueryParamAbsent",
+		ExtractedValueAccepted(extractor.ExtractOptionalQueryParameter(name), IsAbsent()),
+		name)
 }
@@ -56,3 +56,13 @@ func TestQueryParamMatches(t *testing.T) {
 	assert.True(t, QueryParamMatches("q", truePattern).Accept(req))
 	assert.False(t, QueryParamMatches("q", falsePattern).Accept(req))
 }
+
+func TestQueryParamExists(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/test/foo/bar?q=&debug&l=3", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	assert.True(t, QueryParamExists("q").Accept(req), "expected true.")
+	assert.True(t, QueryParamExists("debug").Accept(req), "expected true.")
+	assert.False(t, QueryParamExists("x").Accept(req), "expected false.")
+	assert.False(t, QueryParamAbsent("q").Accept(req), "expected false.")
+	assert.True(t, QueryParamAbsent("x").Accept(req), "expected true.")
+}
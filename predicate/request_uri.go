@@ -18,15 +18,19 @@ import (
 
 // RequestURIMatches returns a predicate that returns true if the request URI matches the pathRegex.
 func RequestURIMatches(pathRegex *regexp.Regexp) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractRequestURI(), StringMatches(pathRegex))
+	return describe("RequestURIMatches",
+		ExtractedValueAccepted(extractor.ExtractRequestURI(), StringMatches(pathRegex)),
+		pathRegex)
 }
 
 // RequestURIEquals returns a predicate that returns true if the request URI equals the path.
 func RequestURIEquals(path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractRequestURI(), StringEquals(path))
+	return describe("RequestURIEquals", ExtractedValueAccepted(extractor.ExtractRequestURI(), StringEquals(path)), path)
 }
 
 // RequestURIStartsWith returns a predicate that returns true if the request URI starts with the path.
 func RequestURIStartsWith(path string) Predicate {
-	return ExtractedValueAccepted(extractor.ExtractRequestURI(), StringStartsWith(path))
+	return describe("RequestURIStartsWith",
+		ExtractedValueAccepted(extractor.ExtractRequestURI(), StringStartsWith(path)),
+		path)
 }
@@ -0,0 +1,172 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StatusIs returns a predicate that returns true if the status code of the response equals 'code'.
+func StatusIs(code int) Predicate {
+	return describe("StatusIs", ExtractedValueAccepted(extractor.ExtractStatusCode(), NumericEquals(code)), code)
+}
+
+// StatusIn returns a predicate that returns true if the status code of the response matches one of the statuses.  A
+// status is either a status code, e.g. "404", or a class of status codes, e.g. "2xx".  StatusIn panics if a status is
+// neither.
+func StatusIn(statuses ...string) Predicate {
+	classes := []string{}
+	predicates := []Predicate{}
+	for _, status := range statuses {
+		s := strings.ToLower(status)
+		if len(s) == 3 && s[0] >= '1' && s[0] <= '9' && s[1:] == "xx" {
+			classes = append(classes, s)
+			continue
+		}
+		code, err := strconv.Atoi(s)
+		if err != nil || code < 100 || code > 999 {
+			panic(fmt.Sprintf("predicate: StatusIn: %q is neither a status code nor a class like \"2xx\"", status))
+		}
+		predicates = append(predicates, ExtractedValueAccepted(extractor.ExtractStatusCode(), NumericEquals(code)))
+	}
+	if len(classes) > 0 {
+		class := ExtractedValueAccepted(extractor.ExtractStatusClass(), StringIn(classes...))
+		predicates = append([]Predicate{class}, predicates...)
+	}
+	p := Or(predicates...)
+	if len(predicates) == 1 {
+		p = predicates[0]
+	}
+	return describe("StatusIn", p, stringArgs(statuses)...)
+}
+
+// ResponseHeaderEquals returns a predicate that returns true if the header named 'name' of the response equals 'value'.
+func ResponseHeaderEquals(name, value string) Predicate {
+	return describe("ResponseHeaderEquals",
+		ExtractedValueAccepted(extractor.ExtractResponseHeader(name), StringEquals(value)),
+		name, value)
+}
+
+// ResponseHeaderContains returns a predicate that returns true if the header named 'name' of the response contains
+// 'value'.
+func ResponseHeaderContains(name, value string) Predicate {
+	return describe("ResponseHeaderContains",
+		ExtractedValueAccepted(extractor.ExtractResponseHeader(name), StringContains(value)),
+		name, value)
+}
+
+// ResponseHeaderMatches returns a predicate that returns true if the header named 'name' of the response matches
+// 'regex'.
+func ResponseHeaderMatches(name string, regex *regexp.Regexp) Predicate {
+	return describe("ResponseHeaderMatches",
+		ExtractedValueAccepted(extractor.ExtractResponseHeader(name), StringMatches(regex)),
+		name, regex)
+}
+
+// ResponseTrailerEquals returns a predicate that returns true if the trailer named 'name' of the response equals
+// 'value'.  The body of the response is buffered first.  See extractor.ExtractTrailer.
+func ResponseTrailerEquals(name, value string) Predicate {
+	return describe("ResponseTrailerEquals",
+		ExtractedValueAccepted(extractor.ExtractTrailer(name), StringEquals(value)),
+		name, value)
+}
+
+// ResponseBodyXPathEquals checks to see if the result of the xpath expression applied to the body of the response
+// equals 'value'.
+func ResponseBodyXPathEquals(xpath, value string) Predicate {
+	return describe("ResponseBodyXPathEquals",
+		ExtractedValueAccepted(extractor.ExtractResponseXPathString(xpath), StringEquals(value)),
+		xpath, value)
+}
+
+// ResponseBodyXPathMatches checks to see if the result of the xpath expression applied to the body of the response
+// matches the regular expression given in the 'pattern' parameter.
+func ResponseBodyXPathMatches(xpath string, pattern *regexp.Regexp) Predicate {
+	return describe("ResponseBodyXPathMatches",
+		ExtractedValueAccepted(extractor.ExtractResponseXPathString(xpath), StringMatches(pattern)),
+		xpath, pattern)
+}
+
+// ResponseBodyJSONPathEquals checks to see if the value selected by the JSONPath expression from the body of the
+// response equals 'value'.  See JSONEquals for how the values are compared.
+func ResponseBodyJSONPathEquals(path string, value interface{}) Predicate {
+	return describe("ResponseBodyJSONPathEquals",
+		ExtractedValueAccepted(extractor.ExtractResponseJSONPath(path), JSONEquals(value)),
+		path, value)
+}
+
+// RequestPredicate is the Predicate returned by RequestMatches.  It tests the Request of a *http.Response with the
+// decorated predicate.
+type RequestPredicate struct {
+	Predicate Predicate
+}
+
+// RequestMatches returns a predicate that returns true if the value is a *http.Response whose Request the predicate
+// accepts, so that the request and the response of an exchange can be tested together, e.g.
+// And(RequestMatches(MethodIs("GET")), StatusIn("5xx")).
+func RequestMatches(p Predicate) Predicate {
+	return RequestPredicate{Predicate: p}
+}
+
+func (rp RequestPredicate) request(v interface{}) (*http.Request, error) {
+	resp, ok := v.(*http.Response)
+	if !ok || resp == nil {
+		return nil, fmt.Errorf("%w: expected a *http.Response, got %T", extractor.ErrWrongType, v)
+	}
+	if resp.Request == nil {
+		return nil, fmt.Errorf("%w: response has no request", extractor.ErrMissing)
+	}
+	return resp.Request, nil
+}
+
+// Accept returns true if the decorated predicate accepts the request of the response.
+func (rp RequestPredicate) Accept(v interface{}) bool {
+	accepted, err := rp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a *http.Response, one wrapping
+// extractor.ErrMissing if the response has no Request and otherwise the error the decorated predicate returns.
+func (rp RequestPredicate) AcceptE(v interface{}) (bool, error) {
+	r, err := rp.request(v)
+	if err != nil {
+		return false, err
+	}
+	return AcceptE(rp.Predicate, r)
+}
+
+// Evaluate evaluates the decorated predicate against the request of the response.
+func (rp RequestPredicate) Evaluate(v interface{}) *Result {
+	r, err := rp.request(v)
+	if err != nil {
+		return &Result{Predicate: rp, Value: v, Err: err}
+	}
+	child := Evaluate(rp.Predicate, r)
+	result := &Result{Predicate: rp, Accepted: child.Accepted, Value: v, Children: []*Result{child}}
+	result.Err = child.propagated()
+	return result
+}
+
+// Describe describes the predicate and the predicate it decorates.
+func (rp RequestPredicate) Describe() Description {
+	return Description{Name: "RequestMatches", Children: []Description{extractor.Describe(rp.Predicate)}}
+}
+
+// String renders the predicate as a function call, e.g. RequestMatches(MethodIs("GET")).
+func (rp RequestPredicate) String() string {
+	return rp.Describe().String()
+}
@@ -0,0 +1,77 @@
+package predicate_test
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func newResponse(status int, contentType, body string) *http.Response {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", contentType)
+	rec.Header().Set("Cache-Control", "private, no-store")
+	rec.WriteHeader(status)
+	rec.WriteString(body)
+	return rec.Result()
+}
+
+func TestStatusPredicates(t *testing.T) {
+	resp := newResponse(201, "text/plain", "")
+	assert.True(t, StatusIs(201).Accept(resp))
+	assert.False(t, StatusIs(200).Accept(resp))
+	assert.True(t, StatusIn("2xx").Accept(resp))
+	assert.True(t, StatusIn("4XX", "201").Accept(resp))
+	assert.False(t, StatusIn("3xx", "404").Accept(resp))
+	assert.False(t, StatusIs(201).Accept(httptest.NewRequest("GET", "/", nil)))
+	assert.Panics(t, func() { StatusIn("2x") })
+
+	_, explanation := Explain(StatusIs(200), resp)
+	assert.Equal(t, "status code: expected to equal 200, got 201", explanation)
+	assert.Equal(t, `StatusIn("4XX", "201")`, StatusIn("4XX", "201").(DescribedPredicate).String())
+}
+
+func TestResponseHeaderPredicates(t *testing.T) {
+	resp := newResponse(200, "application/json", "{}")
+	assert.True(t, ResponseHeaderEquals("content-type", "application/json").Accept(resp))
+	assert.True(t, ResponseHeaderContains("Cache-Control", "no-store").Accept(resp))
+	assert.True(t, ResponseHeaderMatches("Cache-Control", regexp.MustCompile("^private")).Accept(resp))
+	assert.False(t, ResponseHeaderEquals("ETag", "x").Accept(resp))
+}
+
+func TestResponseBodyPredicates(t *testing.T) {
+	resp := newResponse(200, "application/xml", "<order><id>7</id></order>")
+	assert.True(t, ResponseBodyXPathEquals("/order/id", "7").Accept(resp))
+	assert.True(t, ResponseBodyXPathMatches("/order/id", regexp.MustCompile("^[0-9]+$")).Accept(resp))
+	assert.False(t, ResponseBodyXPathEquals("/order/id", "8").Accept(resp))
+
+	resp = newResponse(200, "application/json", `{"id": 7, "tags": ["a"]}`)
+	assert.True(t, ResponseBodyJSONPathEquals("$.id", 7).Accept(resp))
+	assert.True(t, ResponseBodyJSONPathEquals("$.tags", []string{"a"}).Accept(resp))
+	assert.True(t, And(StatusIn("2xx"), ResponseHeaderEquals("Content-Type", "application/json")).Accept(resp))
+	assert.False(t, ResponseTrailerEquals("Grpc-Status", "0").Accept(resp))
+}
+
+func TestRequestMatches(t *testing.T) {
+	resp := newResponse(503, "text/plain", "down")
+	resp.Request = httptest.NewRequest("GET", "/orders", nil)
+	p := And(RequestMatches(MethodIs("GET")), StatusIn("5xx"))
+	assert.True(t, p.Accept(resp))
+	assert.False(t, RequestMatches(PathEquals("/users")).Accept(resp))
+	assert.Equal(t, `RequestMatches(MethodIs("GET"))`, RequestMatches(MethodIs("GET")).(RequestPredicate).String())
+
+	_, explanation := Explain(And(RequestMatches(PathEquals("/users")), StatusIs(503)), resp)
+	assert.Equal(t, "path: expected '/users', got '/orders'", explanation)
+
+	resp.Request = nil
+	_, err := AcceptE(RequestMatches(MethodIs("GET")), resp)
+	assert.True(t, errors.Is(err, extractor.ErrMissing), "%v", err)
+	_, explanation = Explain(RequestMatches(MethodIs("GET")), resp)
+	assert.Equal(t, "value missing: response has no request", explanation)
+	_, err = AcceptE(RequestMatches(MethodIs("GET")), httptest.NewRequest("GET", "/", nil))
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+}
@@ -16,38 +16,64 @@ import (
 	"strings"
 )
 
+// StringPredicate is the Predicate returned by the String* functions.  It compares the value passed to Accept with
+// Expected using Operator, which is one of "equals", "contains", "starts with", "ends with", "is one of" or "matches".
+type StringPredicate struct {
+	Operator string
+	Expected interface{}
+	Func     func(string) bool
+}
+
+// Accept returns true if the value passed is a string that satisfies the comparison.  Any other value is rejected.
+func (sp StringPredicate) Accept(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && sp.Func(s)
+}
+
 // StringEquals returns a predicate that returns true if the value passed is a string and is equal to the value of
 // 'value'
 func StringEquals(value string) Predicate {
-	return PredicateFunc(func(s interface{}) bool {
-		return s.(string) == value
-	})
+	return StringPredicate{"equals", value, func(s string) bool {
+		return s == value
+	}}
 }
 
 // StringContains returns a predicate that returns true if the value passed contains a substring matching 'value'.
 func StringContains(value string) Predicate {
-	return PredicateFunc(func(s interface{}) bool {
-		return strings.Contains(s.(string), value)
-	})
+	return StringPredicate{"contains", value, func(s string) bool {
+		return strings.Contains(s, value)
+	}}
 }
 
 // StringStartsWith returns a predicate that returns true if the value passed starts with a substring matching 'value'.
 func StringStartsWith(value string) Predicate {
-	return PredicateFunc(func(s interface{}) bool {
-		return strings.HasPrefix(s.(string), value)
-	})
+	return StringPredicate{"starts with", value, func(s string) bool {
+		return strings.HasPrefix(s, value)
+	}}
 }
 
 // StringEndsWith returns a predicate that returns true if the value passed ends with a substring matching 'value'.
 func StringEndsWith(value string) Predicate {
-	return PredicateFunc(func(s interface{}) bool {
-		return strings.HasSuffix(s.(string), value)
-	})
+	return StringPredicate{"ends with", value, func(s string) bool {
+		return strings.HasSuffix(s, value)
+	}}
+}
+
+// StringIn returns a predicate that returns true if the value passed is a string equal to one of 'values'.
+func StringIn(values ...string) Predicate {
+	return StringPredicate{"is one of", values, func(s string) bool {
+		for _, value := range values {
+			if s == value {
+				return true
+			}
+		}
+		return false
+	}}
 }
 
 // StringMatches returns a predicate that returns true if the regex matches 'value'.
 func StringMatches(regex *regexp.Regexp) Predicate {
-	return PredicateFunc(func(s interface{}) bool {
-		return regex.MatchString(s.(string))
-	})
+	return StringPredicate{"matches", regex, func(s string) bool {
+		return regex.MatchString(s)
+	}}
 }
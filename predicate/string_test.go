@@ -27,6 +27,13 @@ func TestStringEquals(t *testing.T) {
 	assert.False(t, StringEquals("barfoo").Accept("foobar"))
 }
 
+func TestStringIn(t *testing.T) {
+	assert.True(t, StringIn("foo", "foobar").Accept("foobar"))
+	assert.False(t, StringIn("foo", "bar").Accept("foobar"))
+	assert.False(t, StringIn().Accept(""))
+	assert.Equal(t, `StringIn("foo", "bar")`, StringIn("foo", "bar").(StringPredicate).String())
+}
+
 func TestStringMatches(t *testing.T) {
 	truePattern := regexp.MustCompile("fo{2}bar")
 	falsePattern := regexp.MustCompile("barfo{2}")
@@ -0,0 +1,195 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"regexp"
+	"strings"
+)
+
+// HostPredicate is the Predicate returned by HostIn.  It tests whether a host name matches one of Patterns.
+type HostPredicate struct {
+	Patterns   []string
+	normalized []string
+}
+
+// HostIn returns a predicate that returns true if the value passed is a host name that matches one of the patterns.  A
+// pattern is either a host name or a wildcard like "*.example.com", which matches any subdomain of example.com, however
+// deep, but not example.com itself.  Both the host names and the patterns are compared once normalized by
+// extractor.NormalizeHost, so that case and the encoding of internationalized names do not matter.
+func HostIn(patterns ...string) Predicate {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "*.") {
+			normalized = append(normalized, "*."+extractor.NormalizeHost(pattern[2:]))
+		} else {
+			normalized = append(normalized, extractor.NormalizeHost(pattern))
+		}
+	}
+	return HostPredicate{Patterns: patterns, normalized: normalized}
+}
+
+// Accept returns true if the value passed is a string that matches one of the patterns.  Any other value is rejected.
+func (hp HostPredicate) Accept(v interface{}) bool {
+	accepted, err := hp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a string.
+func (hp HostPredicate) AcceptE(v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, fmt.Errorf("%w: expected a host name, got %T", extractor.ErrWrongType, v)
+	}
+	host := extractor.NormalizeHost(s)
+	for _, pattern := range hp.normalized {
+		if pattern == host {
+			return true, nil
+		}
+		if strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:]) && len(host) > len(pattern)-1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Evaluate matches the host name against the patterns and records the match made.
+func (hp HostPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := hp.AcceptE(v)
+	return &Result{Predicate: hp, Accepted: accepted, Value: v, Operator: "is host in", Expected: hp.Patterns, Err: err}
+}
+
+// Describe returns the name of the function that built the predicate and the patterns.
+func (hp HostPredicate) Describe() Description {
+	return Description{Name: "HostIn", Args: stringArgs(hp.Patterns)}
+}
+
+// String renders the predicate as a function call, e.g. HostIn("*.example.com").
+func (hp HostPredicate) String() string {
+	return hp.Describe().String()
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(value))
+	}
+	return lowered
+}
+
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return args
+}
+
+// SchemeEquals returns a predicate that returns true if the scheme the client used, as returned by
+// extractor.ExtractScheme, equals 'scheme', ignoring case.  Only extractor.DefaultTrustedProxies are trusted to report
+// the scheme unless the predicate is wrapped by WithTrustedProxies.
+func SchemeEquals(scheme string) Predicate {
+	return describe("SchemeEquals",
+		ExtractedValueAccepted(extractor.ExtractScheme(), StringEquals(strings.ToLower(scheme))),
+		scheme)
+}
+
+// SchemeMatches returns a predicate that returns true if the lower case scheme the client used matches the regex.
+func SchemeMatches(regex *regexp.Regexp) Predicate {
+	return describe("SchemeMatches", ExtractedValueAccepted(extractor.ExtractScheme(), StringMatches(regex)), regex)
+}
+
+// SchemeIn returns a predicate that returns true if the scheme the client used is one of 'schemes', ignoring case.
+func SchemeIn(schemes ...string) Predicate {
+	return describe("SchemeIn", ExtractedValueAccepted(extractor.ExtractScheme(), StringIn(lowerAll(schemes)...)),
+		stringArgs(schemes)...)
+}
+
+// HostnameEquals returns a predicate that returns true if the host name of the request, without the port, equals
+// 'hostname' once both are normalized by extractor.NormalizeHost, e.g. HostnameEquals("Bücher.example") accepts a
+// request for "xn--bcher-kva.example:8080".
+func HostnameEquals(hostname string) Predicate {
+	return describe("HostnameEquals",
+		ExtractedValueAccepted(extractor.ExtractHostname(), StringEquals(extractor.NormalizeHost(hostname))),
+		hostname)
+}
+
+// HostnameMatches returns a predicate that returns true if the host name of the request, as returned by
+// extractor.ExtractHostname, matches the regex.  Internationalized names are matched in their punycode form.
+func HostnameMatches(regex *regexp.Regexp) Predicate {
+	return describe("HostnameMatches", ExtractedValueAccepted(extractor.ExtractHostname(), StringMatches(regex)),
+		regex)
+}
+
+// HostnameIn returns a predicate that returns true if the host name of the request matches one of the host names or
+// wildcards, e.g. HostnameIn("example.com", "*.example.com").  See HostIn.
+func HostnameIn(hostnames ...string) Predicate {
+	return describe("HostnameIn", ExtractedValueAccepted(extractor.ExtractHostname(), HostIn(hostnames...)),
+		stringArgs(hostnames)...)
+}
+
+// PortEquals returns a predicate that returns true if the port of the request, as returned by extractor.ExtractPort,
+// equals 'port'.  The default port of the scheme is used if the host has none, see SchemeEquals.
+func PortEquals(port string) Predicate {
+	return describe("PortEquals", ExtractedValueAccepted(extractor.ExtractPort(), StringEquals(port)), port)
+}
+
+// PortMatches returns a predicate that returns true if the port of the request matches the regex.
+func PortMatches(regex *regexp.Regexp) Predicate {
+	return describe("PortMatches", ExtractedValueAccepted(extractor.ExtractPort(), StringMatches(regex)), regex)
+}
+
+// PortIn returns a predicate that returns true if the port of the request is one of 'ports'.
+func PortIn(ports ...string) Predicate {
+	return describe("PortIn", ExtractedValueAccepted(extractor.ExtractPort(), StringIn(ports...)), stringArgs(ports)...)
+}
+
+// RawPathEquals returns a predicate that returns true if the path of the request, as it was sent, equals 'path'.  See
+// extractor.ExtractRawPath.
+func RawPathEquals(path string) Predicate {
+	return describe("RawPathEquals", ExtractedValueAccepted(extractor.ExtractRawPath(), StringEquals(path)), path)
+}
+
+// RawPathMatches returns a predicate that returns true if the path of the request, as it was sent, matches the regex.
+func RawPathMatches(regex *regexp.Regexp) Predicate {
+	return describe("RawPathMatches", ExtractedValueAccepted(extractor.ExtractRawPath(), StringMatches(regex)), regex)
+}
+
+// RawPathIn returns a predicate that returns true if the path of the request, as it was sent, is one of 'paths'.
+func RawPathIn(paths ...string) Predicate {
+	return describe("RawPathIn", ExtractedValueAccepted(extractor.ExtractRawPath(), StringIn(paths...)),
+		stringArgs(paths)...)
+}
+
+// EscapedPathEquals returns a predicate that returns true if the escaped path of the request's URL equals 'path'.  See
+// extractor.ExtractEscapedPath.
+func EscapedPathEquals(path string) Predicate {
+	return describe("EscapedPathEquals",
+		ExtractedValueAccepted(extractor.ExtractEscapedPath(), StringEquals(path)),
+		path)
+}
+
+// EscapedPathMatches returns a predicate that returns true if the escaped path of the request's URL matches the
+// regex.
+func EscapedPathMatches(regex *regexp.Regexp) Predicate {
+	return describe("EscapedPathMatches",
+		ExtractedValueAccepted(extractor.ExtractEscapedPath(), StringMatches(regex)),
+		regex)
+}
+
+// EscapedPathIn returns a predicate that returns true if the escaped path of the request's URL is one of 'paths'.
+func EscapedPathIn(paths ...string) Predicate {
+	return describe("EscapedPathIn", ExtractedValueAccepted(extractor.ExtractEscapedPath(), StringIn(paths...)),
+		stringArgs(paths)...)
+}
@@ -0,0 +1,73 @@
+package predicate_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestHostIn(t *testing.T) {
+	assert.True(t, HostIn("example.com").Accept("EXAMPLE.com"))
+	assert.True(t, HostIn("*.example.com").Accept("api.example.com"))
+	assert.True(t, HostIn("*.example.com").Accept("a.b.example.com"))
+	assert.False(t, HostIn("*.example.com").Accept("example.com"))
+	assert.False(t, HostIn("*.example.com").Accept("badexample.com"))
+	assert.True(t, HostIn("*.bücher.example").Accept("shop.xn--bcher-kva.example"))
+	assert.Equal(t, `HostIn("*.example.com")`, HostIn("*.example.com").(fmt.Stringer).String())
+
+	accepted, explanation := Explain(HostIn("example.com", "*.example.org"), "example.net")
+	assert.False(t, accepted)
+	assert.Equal(t, `expected one of ["example.com" "*.example.org"], got 'example.net'`, explanation)
+	_, err := AcceptE(HostIn("example.com"), 7)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+}
+
+func TestURLPredicates(t *testing.T) {
+	req := httptest.NewRequest("GET", "/files/a%2Fb", nil)
+	req.Host = "API.Bücher.example:8443"
+	req.TLS = &tls.ConnectionState{}
+
+	assert.True(t, SchemeEquals("HTTPS").Accept(req))
+	assert.True(t, SchemeMatches(regexp.MustCompile("^https?$")).Accept(req))
+	assert.True(t, SchemeIn("HTTP", "HTTPS").Accept(req))
+	assert.False(t, SchemeIn("ws", "wss").Accept(req))
+	assert.True(t, HostnameEquals("api.xn--bcher-kva.example").Accept(req))
+	assert.True(t, HostnameEquals("api.bücher.example.").Accept(req))
+	assert.True(t, HostnameMatches(regexp.MustCompile(`^api\.`)).Accept(req))
+	assert.True(t, HostnameIn("localhost", "*.bücher.example").Accept(req))
+	assert.False(t, HostnameIn("bücher.example").Accept(req))
+	assert.True(t, PortEquals("8443").Accept(req))
+	assert.True(t, PortMatches(regexp.MustCompile("^84")).Accept(req))
+	assert.False(t, PortIn("80", "443").Accept(req))
+	assert.True(t, RawPathEquals("/files/a%2Fb").Accept(req))
+	assert.True(t, RawPathMatches(regexp.MustCompile("%2F")).Accept(req))
+	assert.True(t, RawPathIn("/", "/files/a%2Fb").Accept(req))
+	assert.True(t, EscapedPathEquals("/files/a%2Fb").Accept(req))
+	assert.False(t, EscapedPathMatches(regexp.MustCompile("^/files/a/b$")).Accept(req))
+	assert.True(t, EscapedPathIn("/files/a%2Fb").Accept(req))
+
+	accepted, explanation := Explain(PortIn("80", "443"), req)
+	assert.False(t, accepted)
+	assert.Equal(t, `port: expected one of ["80" "443"], got '8443'`, explanation)
+	assert.Equal(t, `HostnameIn("localhost", "*.bücher.example")`,
+		HostnameIn("localhost", "*.bücher.example").(DescribedPredicate).String())
+}
+
+func TestURLPredicates_WithTrustedProxies(t *testing.T) {
+	req := httptest.NewRequest("GET", "/a", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-Proto", "https")
+
+	p := And(SchemeIn("https", "wss"), PortEquals("443"))
+	assert.False(t, SchemeEquals("https").Accept(req))
+	assert.False(t, p.Accept(req))
+	assert.True(t, WithTrustedProxies(SchemeEquals("https"), "10.0.0.1").Accept(req))
+	assert.True(t, WithTrustedProxies(p, "10.0.0.0/8").Accept(req))
+	assert.False(t, WithTrustedProxies(p, "192.168.0.0/16").Accept(req))
+}
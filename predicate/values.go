@@ -0,0 +1,138 @@
+package predicate
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+)
+
+// ValuesPredicate is the Predicate returned by AnyValue, AllValues, ValueCount and ContainsAll.  It tests a list of
+// values, either a []string, like the ones returned by extractor.ExtractHeaderValues and
+// extractor.ExtractQueryParameterValues, or a []interface{}.  Operator is one of "any value", "all values", "value
+// count" or "contains all".  Expected is the predicate applied to the values, the number of values or the values that
+// must be present.  Any other value is rejected.
+type ValuesPredicate struct {
+	Operator string
+	Expected interface{}
+	Func     func([]interface{}) (bool, error)
+}
+
+// AnyValue returns a predicate that returns true if the predicate accepts at least one of the values.
+func AnyValue(predicate Predicate) Predicate {
+	return ValuesPredicate{"any value", predicate, func(values []interface{}) (bool, error) {
+		for _, v := range values {
+			accepted, err := AcceptE(predicate, v)
+			if err != nil || accepted {
+				return accepted && err == nil, err
+			}
+		}
+		return false, nil
+	}}
+}
+
+// AllValues returns a predicate that returns true if there is at least one value and the predicate accepts all of
+// them.
+func AllValues(predicate Predicate) Predicate {
+	return ValuesPredicate{"all values", predicate, func(values []interface{}) (bool, error) {
+		for _, v := range values {
+			accepted, err := AcceptE(predicate, v)
+			if err != nil || !accepted {
+				return false, err
+			}
+		}
+		return len(values) > 0, nil
+	}}
+}
+
+// ValueCount returns a predicate that returns true if there are exactly 'n' values.
+func ValueCount(n int) Predicate {
+	return ValuesPredicate{"value count", n, func(values []interface{}) (bool, error) {
+		return len(values) == n, nil
+	}}
+}
+
+// ContainsAll returns a predicate that returns true if every one of 'expected' is equal to one of the values.
+func ContainsAll(expected ...string) Predicate {
+	return ValuesPredicate{"contains all", expected, func(values []interface{}) (bool, error) {
+		present := make(map[interface{}]bool, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				present[s] = true
+			}
+		}
+		for _, s := range expected {
+			if !present[s] {
+				return false, nil
+			}
+		}
+		return true, nil
+	}}
+}
+
+// Accept returns true if the value passed is a list of values that satisfies the test.
+func (vp ValuesPredicate) Accept(v interface{}) bool {
+	accepted, err := vp.AcceptE(v)
+	return accepted && err == nil
+}
+
+// AcceptE returns an error wrapping extractor.ErrWrongType if the value is not a []string or a []interface{}.  Errors
+// returned by the predicate applied to the values are returned as is.
+func (vp ValuesPredicate) AcceptE(v interface{}) (bool, error) {
+	var values []interface{}
+	switch list := v.(type) {
+	case []interface{}:
+		values = list
+	case []string:
+		values = make([]interface{}, 0, len(list))
+		for _, s := range list {
+			values = append(values, s)
+		}
+	default:
+		return false, fmt.Errorf("%w: expected a list of values, got %T", extractor.ErrWrongType, v)
+	}
+	return vp.Func(values)
+}
+
+// Evaluate tests the values and records the test made.
+func (vp ValuesPredicate) Evaluate(v interface{}) *Result {
+	accepted, err := vp.AcceptE(v)
+	return &Result{Predicate: vp, Accepted: accepted, Value: v, Operator: vp.Operator, Expected: vp.Expected, Err: err}
+}
+
+var valuesPredicateNames = map[string]string{
+	"any value":    "AnyValue",
+	"all values":   "AllValues",
+	"value count":  "ValueCount",
+	"contains all": "ContainsAll",
+}
+
+// Describe returns the name of the function that built the predicate and its arguments.
+func (vp ValuesPredicate) Describe() Description {
+	description := Description{Name: valuesPredicateNames[vp.Operator]}
+	switch expected := vp.Expected.(type) {
+	case Predicate:
+		description.Children = []Description{extractor.Describe(expected)}
+	case []string:
+		for _, s := range expected {
+			description.Args = append(description.Args, s)
+		}
+	default:
+		description.Args = []interface{}{expected}
+	}
+	return description
+}
+
+// String renders the predicate as a function call, e.g. AnyValue(StringContains("json")).
+func (vp ValuesPredicate) String() string {
+	return vp.Describe().String()
+}
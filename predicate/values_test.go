@@ -0,0 +1,68 @@
+package predicate_test
+
+import (
+	"errors"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	. "github.com/danapsimer/go-http-matchers/predicate"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestValuesPredicates(t *testing.T) {
+	values := []string{"text/html", "application/json", "application/xml"}
+	tests := []struct {
+		Predicate Predicate
+		Expected  bool
+	}{
+		{AnyValue(StringEquals("application/json")), true},
+		{AnyValue(StringEquals("text/plain")), false},
+		{AnyValue(StringMatches(regexp.MustCompile("^text/"))), true},
+		{AllValues(StringContains("/")), true},
+		{AllValues(StringStartsWith("application/")), false},
+		{ValueCount(3), true},
+		{ValueCount(1), false},
+		{ContainsAll("application/xml", "text/html"), true},
+		{ContainsAll("application/xml", "text/plain"), false},
+		{ContainsAll(), true},
+	}
+	for _, tst := range tests {
+		assert.Equal(t, tst.Expected, tst.Predicate.Accept(values), "%v", tst.Predicate)
+	}
+
+	assert.False(t, AnyValue(True()).Accept([]string{}))
+	assert.False(t, AllValues(True()).Accept([]string{}))
+	assert.True(t, ValueCount(0).Accept([]string{}))
+	assert.True(t, AnyValue(JSONEquals(2)).Accept([]interface{}{1.0, 2.0}))
+
+	accepted, err := AcceptE(ValueCount(1), "a")
+	assert.False(t, accepted)
+	assert.True(t, errors.Is(err, extractor.ErrWrongType), "%v", err)
+}
+
+func TestValuesPredicates_Request(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://foo.com/items?tag=a&tag=b", nil)
+	assert.NoError(t, err, "failed to create test request.")
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json")
+
+	accept := extractor.ExtractHeaderValues("Accept")
+	assert.True(t, ExtractedValueAccepted(accept, AnyValue(StringContains("json"))).Accept(req))
+	assert.False(t, HeaderContains("Accept", "json").Accept(req))
+	assert.True(t, ExtractedValueAccepted(accept, ValueCount(2)).Accept(req))
+
+	tags := extractor.ExtractQueryParameterValues("tag")
+	assert.True(t, ExtractedValueAccepted(tags, ContainsAll("b", "a")).Accept(req))
+	assert.True(t, ExtractedValueAccepted(extractor.ExtractQueryParameterValues("x"), ValueCount(0)).Accept(req))
+
+	p := ExtractedValueAccepted(tags, AllValues(StringEquals("a")))
+	accepted, explanation := Explain(p, req)
+	assert.False(t, accepted)
+	assert.Equal(t, `query parameter tag values: expected all values to be accepted by StringEquals("a"), got [a b]`,
+		explanation)
+	assert.Equal(t, `ExtractedValueAccepted(ExtractQueryParameterValues("tag"), AllValues(StringEquals("a")))`,
+		p.(ExtractedValuePredicate).String())
+	assert.Equal(t, `ContainsAll("a", "b")`, ContainsAll("a", "b").(ValuesPredicate).String())
+	assert.Equal(t, `ValueCount(2)`, ValueCount(2).(ValuesPredicate).String())
+}
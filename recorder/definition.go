@@ -0,0 +1,76 @@
+package recorder
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/codec"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+)
+
+// Definition is the serialized form of a recording: its predicate encoded by the codec package and its response.  It
+// marshals to JSON and YAML, e.g.
+//
+//	{"predicate": {"type": "And", "predicates": [
+//	   {"type": "MethodIs", "args": ["GET"]},
+//	   {"type": "PathEquals", "args": ["/orders/42"]}
+//	 ]},
+//	 "response": {"status": 200, "header": {"Content-Type": ["application/json"]}, "body": "{\"id\": \"42\"}"}}
+//
+// The body is a string, so binary bodies do not survive a round trip through JSON.
+type Definition struct {
+	Predicate *codec.Node         `json:"predicate" yaml:"predicate"`
+	Response  mockserver.Response `json:"response" yaml:"response"`
+}
+
+// Definitions returns the definitions of the proxy's recordings.
+func (p *Proxy) Definitions() ([]*Definition, error) {
+	recordings := p.Recordings()
+	definitions := make([]*Definition, 0, len(recordings))
+	for i, recording := range recordings {
+		node, err := codec.Encode(recording.Predicate)
+		if err != nil {
+			return nil, fmt.Errorf("recording %d: %w", i+1, err)
+		}
+		definitions = append(definitions, &Definition{Predicate: node, Response: recording.Response})
+	}
+	return definitions, nil
+}
+
+// Load adds the recordings defined by the definitions to the proxy, e.g. to replay them.
+func (p *Proxy) Load(definitions []*Definition) error {
+	recordings := make([]*Recording, 0, len(definitions))
+	for i, definition := range definitions {
+		pred, err := codec.Decode(definition.Predicate)
+		if err != nil {
+			return fmt.Errorf("definition %d: %w", i+1, err)
+		}
+		recordings = append(recordings, &Recording{Predicate: pred, Response: definition.Response})
+	}
+	for _, recording := range recordings {
+		p.Add(recording)
+	}
+	return nil
+}
+
+// Stub registers a stub on the mock server for every definition.  Unlike a Proxy in Replay mode, the server always
+// answers with the first definition that accepts the request.
+func Stub(server *mockserver.Server, definitions []*Definition) error {
+	for i, definition := range definitions {
+		pred, err := codec.Decode(definition.Predicate)
+		if err != nil {
+			return fmt.Errorf("definition %d: %w", i+1, err)
+		}
+		server.Stub(pred, definition.Response)
+	}
+	return nil
+}
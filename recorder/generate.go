@@ -0,0 +1,81 @@
+package recorder
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"sort"
+)
+
+// Generator generates the predicate that matches a recorded request.  The predicate always requires the method and
+// the path of the recorded request.  A tight generator also requires every query parameter, except the ignored ones,
+// and every one of Headers to have the recorded values, e.g.
+//
+//	And(MethodIs("GET"), PathEquals("/orders"), QueryParamEquals("page", "2"), HeaderEquals("X-Tenant", "a"))
+//
+// while a loose generator only requires them to be present:
+//
+//	And(MethodIs("GET"), PathEquals("/orders"), QueryParamExists("page"), HeaderExists("X-Tenant"))
+//
+// The zero value is a tight generator that ignores all headers and no query parameters.
+type Generator struct {
+	// Loose, if true, requires the query parameters and headers to be present rather than to equal the recorded values.
+	Loose bool
+	// Headers are the names of the request headers the predicate tests.  A tight predicate requires the headers the
+	// recorded request did not have to be absent.
+	Headers []string
+	// IgnoreQueryParams are the names of the query parameters the predicate does not test, e.g. cache busters or
+	// timestamps.
+	IgnoreQueryParams []string
+}
+
+// Predicate returns the predicate that matches the request.
+func (g Generator) Predicate(r *http.Request) predicate.Predicate {
+	predicates := []predicate.Predicate{predicate.MethodIs(r.Method), predicate.PathEquals(r.URL.Path)}
+	query := r.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		if !contains(g.IgnoreQueryParams, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if g.Loose {
+			predicates = append(predicates, predicate.QueryParamExists(name))
+		} else {
+			predicates = append(predicates, predicate.QueryParamEquals(name, query.Get(name)))
+		}
+	}
+	for _, name := range g.Headers {
+		_, present := r.Header[http.CanonicalHeaderKey(name)]
+		switch {
+		case present && g.Loose:
+			predicates = append(predicates, predicate.HeaderExists(name))
+		case present:
+			predicates = append(predicates, predicate.HeaderEquals(name, r.Header.Get(name)))
+		case !g.Loose:
+			predicates = append(predicates, predicate.HeaderAbsent(name))
+		}
+	}
+	return predicate.And(predicates...)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
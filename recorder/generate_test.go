@@ -0,0 +1,37 @@
+package recorder_test
+
+import (
+	"fmt"
+	. "github.com/danapsimer/go-http-matchers/recorder"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGenerator_Tight(t *testing.T) {
+	req := httptest.NewRequest("GET", "/orders?page=2&_=1714564800&sort=id", nil)
+	req.Header.Set("X-Tenant", "a")
+	g := Generator{Headers: []string{"X-Tenant", "X-Debug"}, IgnoreQueryParams: []string{"_"}}
+	p := g.Predicate(req)
+	assert.Equal(t, `And(MethodIs("GET"), PathEquals("/orders"), QueryParamEquals("page", "2"), `+
+		`QueryParamEquals("sort", "id"), HeaderEquals("X-Tenant", "a"), HeaderAbsent("X-Debug"))`, fmt.Sprint(p))
+	assert.True(t, p.Accept(req))
+
+	other := httptest.NewRequest("GET", "/orders?page=3&_=1&sort=id", nil)
+	other.Header.Set("X-Tenant", "a")
+	assert.False(t, p.Accept(other))
+	other = httptest.NewRequest("GET", "/orders?page=2&_=1&sort=id", nil)
+	other.Header.Set("X-Tenant", "a")
+	assert.True(t, p.Accept(other))
+}
+
+func TestGenerator_Loose(t *testing.T) {
+	req := httptest.NewRequest("POST", "/orders?page=2", nil)
+	req.Header.Set("X-Tenant", "a")
+	p := Generator{Loose: true, Headers: []string{"X-Tenant", "X-Debug"}}.Predicate(req)
+	other := httptest.NewRequest("POST", "/orders?page=9", nil)
+	other.Header.Set("X-Tenant", "b")
+	other.Header.Set("X-Debug", "1")
+	assert.True(t, p.Accept(other))
+	assert.False(t, p.Accept(httptest.NewRequest("POST", "/orders", nil)))
+}
@@ -0,0 +1,228 @@
+// Package recorder provides a reverse proxy that records the traffic it forwards as stubs and replays them, so that
+// the traffic of a real service, e.g. in staging, can be turned into an offline mock:
+//
+//	proxy := recorder.NewProxy(target)
+//	proxy.Generator = recorder.Generator{Headers: []string{"X-Tenant"}, IgnoreQueryParams: []string{"_"}}
+//
+//	// ... send traffic through the proxy ...
+//
+//	definitions, err := proxy.Definitions()
+//	data, err := json.MarshalIndent(definitions, "", "  ")
+//
+// The request of every exchange is turned into a predicate by the proxy's Generator.  Definitions pairs each predicate,
+// encoded by the codec package, with the recorded response so that they can be saved and loaded again with Load.  In
+// Replay mode the proxy no longer forwards requests: it answers them with the recorded responses of the recordings
+// whose predicates accept the request, in turn.
+package recorder
+
+// Licensed to BlueSoft Development, LLC under one or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information regarding copyright ownership.  BlueSoft Development, LLC
+// licenses this file to you under the Apache License, Version 2.0 (the "License"); you may not use this file except in
+// compliance with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode selects whether a Proxy records or replays.
+type Mode int
+
+const (
+	// Record forwards requests to the target and records the exchanges.  It is the default mode.
+	Record Mode = iota
+	// Replay answers requests with the recorded responses without forwarding them.
+	Replay
+)
+
+var modeNames = map[Mode]string{
+	Record: "record",
+	Replay: "replay",
+}
+
+// String returns the name of the mode, e.g. "replay".
+func (m Mode) String() string {
+	if name, ok := modeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Recording is a recorded exchange.
+type Recording struct {
+	// Request is a copy of the request received by the proxy.  Its body has been buffered and can be inspected by
+	// predicates.  It is nil for the recordings loaded from definitions.
+	Request *http.Request
+	// Predicate matches the requests the recording answers in Replay mode.
+	Predicate predicate.Predicate
+	// Response is the response returned by the target.
+	Response mockserver.Response
+	// Time is when the request was received.
+	Time time.Time
+}
+
+type incomingKey struct{}
+
+// Proxy is an http.Handler that forwards requests to a target and records the exchanges or, in Replay mode, answers
+// requests with the recorded responses.
+type Proxy struct {
+	// Mode selects whether the proxy records or replays.  It must not be changed while the proxy serves requests.
+	Mode Mode
+	// Generator generates the predicates of the recordings.
+	Generator Generator
+	// ReverseProxy forwards the requests in Record mode.  Its ModifyResponse records the responses and must not be
+	// replaced, but its Transport and ErrorHandler can be.
+	ReverseProxy *httputil.ReverseProxy
+
+	mu         sync.Mutex
+	recordings []*Recording
+	// calls counts the requests replayed, by the index of the first recording that accepted them.
+	calls map[int]int
+}
+
+// NewProxy returns a Proxy in Record mode that forwards requests to the target, e.g. "https://staging.example.com".
+// The target's path is prepended to the path of the requests, as by httputil.NewSingleHostReverseProxy.
+func NewProxy(target *url.URL) *Proxy {
+	p := &Proxy{calls: map[int]int{}}
+	p.ReverseProxy = httputil.NewSingleHostReverseProxy(target)
+	p.ReverseProxy.ModifyResponse = p.record
+	return p
+}
+
+// ServeHTTP forwards and records the request in Record mode and replays the recorded response in Replay mode.
+// Requests that no recording accepts are answered in Replay mode with a 404 whose body reports the recording that came
+// closest to accepting the request and why it rejected it.  Requests whose body can not be buffered, because it is
+// larger than extractor.DefaultMaxBodySize or can not be read, are forwarded but not recorded in Record mode and
+// answered with a 413 or a 400 in Replay mode.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, err := extractor.RequestBody(r); err != nil {
+		if p.Mode == Replay {
+			http.Error(w, "request body can not be read: "+err.Error(), bodyErrorStatus(err))
+		} else {
+			p.ReverseProxy.ServeHTTP(w, r)
+		}
+		return
+	}
+	if p.Mode == Replay {
+		p.replay(w, r)
+		return
+	}
+	incoming := r.Clone(context.Background())
+	if r.GetBody != nil {
+		incoming.Body, _ = r.GetBody()
+	}
+	p.ReverseProxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), incomingKey{}, incoming)))
+}
+
+// bodyErrorStatus returns the status of the response to a request whose body could not be buffered because of the
+// error.
+func bodyErrorStatus(err error) int {
+	if errors.Is(err, extractor.ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// record records the response to the request received by the proxy.  The body of the response is buffered so that it
+// is still written to the client.  Responses whose body can not be buffered, because it is larger than
+// extractor.DefaultMaxBodySize or can not be read, are not recorded but still streamed to the client as they are.
+func (p *Proxy) record(resp *http.Response) error {
+	incoming, ok := resp.Request.Context().Value(incomingKey{}).(*http.Request)
+	if !ok {
+		return nil
+	}
+	body, err := extractor.ResponseBody(resp)
+	if err != nil {
+		return nil
+	}
+	data, _ := body.Bytes()
+	p.Add(&Recording{
+		Request:   incoming,
+		Predicate: p.Generator.Predicate(incoming),
+		Response:  mockserver.Response{Status: resp.StatusCode, Header: resp.Header.Clone(), Body: string(data)},
+		Time:      time.Now(),
+	})
+	return nil
+}
+
+// Add adds a recording, e.g. one loaded from a definition.
+func (p *Proxy) Add(recording *Recording) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recordings = append(p.recordings, recording)
+}
+
+// Recordings returns the recordings in the order they were made.
+func (p *Proxy) Recordings() []*Recording {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*Recording(nil), p.recordings...)
+}
+
+// Reset forgets all of the recordings and how many times they were replayed.
+func (p *Proxy) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recordings = nil
+	p.calls = map[int]int{}
+}
+
+// replay writes the recorded response to the request.  The recordings that accept the request, e.g. the same request
+// recorded several times, are replayed in turn and the last one is repeated once all of them have been replayed.
+func (p *Proxy) replay(w http.ResponseWriter, r *http.Request) {
+	recordings := p.Recordings()
+	first := -1
+	var candidates []*Recording
+	for i, recording := range recordings {
+		if recording.Predicate.Accept(r) {
+			if first < 0 {
+				first = i
+			}
+			candidates = append(candidates, recording)
+		}
+	}
+	if len(candidates) == 0 {
+		p.notFound(w, r, recordings)
+		return
+	}
+	p.mu.Lock()
+	call := p.calls[first]
+	p.calls[first]++
+	p.mu.Unlock()
+	if call >= len(candidates) {
+		call = len(candidates) - 1
+	}
+	candidates[call].Response.ServeHTTP(w, r)
+}
+
+func (p *Proxy) notFound(w http.ResponseWriter, r *http.Request, recordings []*Recording) {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "no recording matched %s %s", r.Method, r.URL.RequestURI())
+	predicates := make([]predicate.Predicate, 0, len(recordings))
+	for _, recording := range recordings {
+		predicates = append(predicates, recording.Predicate)
+	}
+	if i, result := predicate.Closest(predicates, r); i >= 0 {
+		fmt.Fprintf(sb, "\nclosest recording: %v", recordings[i].Predicate)
+		for _, line := range strings.Split(result.Explanation(), "\n") {
+			sb.WriteString("\n  " + line)
+		}
+	}
+	http.Error(w, sb.String(), http.StatusNotFound)
+}
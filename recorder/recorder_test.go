@@ -0,0 +1,208 @@
+package recorder_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/danapsimer/go-http-matchers/extractor"
+	"github.com/danapsimer/go-http-matchers/mockserver"
+	"github.com/danapsimer/go-http-matchers/predicate"
+	. "github.com/danapsimer/go-http-matchers/recorder"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func do(t *testing.T, method, url, body string) (*http.Response, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data)
+}
+
+func newTarget(t *testing.T) *httptest.Server {
+	var counter int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&counter, 1)
+		data, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Call", string(rune('0'+n)))
+		if r.URL.Path == "/api/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(r.Method + " " + r.URL.RequestURI() + " " + string(data)))
+	}))
+}
+
+func TestProxy_RecordAndReplay(t *testing.T) {
+	target := newTarget(t)
+	defer target.Close()
+	targetURL, _ := url.Parse(target.URL + "/api")
+	proxy := NewProxy(targetURL)
+	front := httptest.NewServer(proxy)
+	defer front.Close()
+
+	resp, body := do(t, "POST", front.URL+"/orders?page=1", "widget")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "POST /api/orders?page=1 widget", body)
+	resp, _ = do(t, "GET", front.URL+"/missing", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	do(t, "POST", front.URL+"/orders?page=1", "gadget")
+
+	recordings := proxy.Recordings()
+	if assert.Len(t, recordings, 3) {
+		assert.Equal(t, "/orders", recordings[0].Request.URL.Path)
+		assert.Equal(t, `And(MethodIs("POST"), PathEquals("/orders"), QueryParamEquals("page", "1"))`,
+			fmt.Sprint(recordings[0].Predicate))
+		assert.Equal(t, 200, recordings[0].Response.Status)
+		assert.Equal(t, "POST /api/orders?page=1 widget", recordings[0].Response.Body)
+		assert.Equal(t, "1", recordings[0].Response.Header.Get("X-Call"))
+		body, _ := io.ReadAll(recordings[0].Request.Body)
+		assert.Equal(t, "widget", string(body))
+	}
+
+	definitions, err := proxy.Definitions()
+	assert.NoError(t, err)
+	data, err := json.Marshal(definitions)
+	assert.NoError(t, err)
+	var decoded []*Definition
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+
+	target.Close()
+	replay := NewProxy(targetURL)
+	replay.Mode = Replay
+	assert.NoError(t, replay.Load(decoded))
+	replayFront := httptest.NewServer(replay)
+	defer replayFront.Close()
+
+	resp, body = do(t, "POST", replayFront.URL+"/orders?page=1", "anything")
+	assert.Equal(t, "POST /api/orders?page=1 widget", body)
+	assert.Equal(t, "1", resp.Header.Get("X-Call"))
+	_, body = do(t, "POST", replayFront.URL+"/orders?page=1", "")
+	assert.Equal(t, "POST /api/orders?page=1 gadget", body)
+	_, body = do(t, "POST", replayFront.URL+"/orders?page=1", "")
+	assert.Equal(t, "POST /api/orders?page=1 gadget", body)
+	resp, _ = do(t, "GET", replayFront.URL+"/missing", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("X-Call"))
+
+	resp, body = do(t, "POST", replayFront.URL+"/orders?page=2", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, body, "no recording matched POST /orders?page=2")
+	assert.Contains(t, body, `closest recording: And(MethodIs("POST"), PathEquals("/orders"),`)
+	assert.Contains(t, body, "query parameter page: expected '1', got '2'")
+}
+
+func TestProxy_RecordOversizedResponse(t *testing.T) {
+	large := strings.Repeat("x", int(extractor.DefaultMaxBodySize)+1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			w.Write([]byte(large))
+			return
+		}
+		w.Write([]byte("small"))
+	}))
+	defer target.Close()
+	targetURL, _ := url.Parse(target.URL)
+	proxy := NewProxy(targetURL)
+	front := httptest.NewServer(proxy)
+	defer front.Close()
+
+	resp, body := do(t, "GET", front.URL+"/large", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, len(large), len(body))
+	assert.True(t, body == large)
+	_, body = do(t, "GET", front.URL+"/small", "")
+	assert.Equal(t, "small", body)
+
+	recordings := proxy.Recordings()
+	if assert.Len(t, recordings, 1) {
+		assert.Equal(t, "/small", recordings[0].Request.URL.Path)
+	}
+}
+
+func TestProxy_OversizedRequest(t *testing.T) {
+	large := strings.Repeat("x", int(extractor.DefaultMaxBodySize)+1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		fmt.Fprint(w, len(data))
+	}))
+	defer target.Close()
+	targetURL, _ := url.Parse(target.URL)
+	proxy := NewProxy(targetURL)
+	front := httptest.NewServer(proxy)
+	defer front.Close()
+
+	resp, body := do(t, "POST", front.URL+"/upload", large)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fmt.Sprint(len(large)), body)
+	assert.Empty(t, proxy.Recordings())
+
+	proxy.Add(&Recording{Predicate: predicate.PathEquals("/upload"), Response: mockserver.Response{Body: "ok"}})
+	proxy.Mode = Replay
+	resp, body = do(t, "POST", front.URL+"/upload", large)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Contains(t, body, extractor.ErrBodyTooLarge.Error())
+}
+
+func TestProxy_ReplayInTurn(t *testing.T) {
+	proxy := NewProxy(&url.URL{})
+	proxy.Mode = Replay
+	proxy.Add(&Recording{Predicate: predicate.PathStartsWith("/orders"), Response: mockserver.Response{Body: "first"}})
+	proxy.Add(&Recording{Predicate: predicate.PathEquals("/users"), Response: mockserver.Response{Body: "users"}})
+	proxy.Add(&Recording{Predicate: predicate.PathEquals("/orders/42"),
+		Response: mockserver.Response{Status: http.StatusCreated, Header: http.Header{"X-Id": {"42"}}, Body: "second"}})
+
+	replay := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		proxy.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+	assert.Equal(t, "first", replay("/orders/42").Body.String())
+	w := replay("/orders/42")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "42", w.Header().Get("X-Id"))
+	assert.Equal(t, "second", w.Body.String())
+	assert.Equal(t, "second", replay("/orders/42").Body.String())
+	assert.Equal(t, "users", replay("/users").Body.String())
+	assert.Equal(t, http.StatusNotFound, replay("/other").Code)
+
+	proxy.Reset()
+	assert.Equal(t, http.StatusNotFound, replay("/orders/42").Code)
+}
+
+func TestStub(t *testing.T) {
+	definitions := []*Definition{}
+	err := json.Unmarshal([]byte(`[{"predicate": {"type": "PathEquals", "args": ["/orders/42"]},
+		"response": {"status": 200, "header": {"Content-Type": ["application/json"]}, "body": "{\"id\": \"42\"}"}}]`),
+		&definitions)
+	assert.NoError(t, err)
+
+	server := mockserver.NewServer()
+	defer server.Close()
+	assert.NoError(t, Stub(server, definitions))
+	resp, body := do(t, "GET", server.URL+"/orders/42", "")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"id": "42"}`, body)
+
+	definitions[0].Predicate.Type = "Unknown"
+	assert.Error(t, Stub(server, definitions))
+	assert.Error(t, NewProxy(&url.URL{}).Load(definitions))
+}
+
+func TestMode_String(t *testing.T) {
+	assert.Equal(t, "record", Record.String())
+	assert.Equal(t, "replay", Replay.String())
+	assert.Equal(t, "Mode(7)", Mode(7).String())
+}